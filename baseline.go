@@ -0,0 +1,230 @@
+package disco
+
+import (
+	"errors"
+	"slices"
+	"sort"
+)
+
+// A baseline recommender.
+type Baseline[T Id, U Id] struct {
+	userMap    map[T]int
+	itemMap    map[U]int
+	userIds    []T
+	itemIds    []U
+	rated      []map[int]bool
+	userScores []float32
+	itemScores []float32
+	random     bool
+	seed       uint64
+}
+
+// Creates a baseline recommender that recommends items with the most ratings.
+func FitPopular[T Id, U Id](trainSet *Dataset[T, U]) (*Baseline[T, U], error) {
+	b, err := newBaseline(trainSet)
+	if err != nil {
+		return nil, err
+	}
+	for _, rating := range trainSet.data {
+		b.itemScores[b.itemMap[rating.itemId]] += 1.0
+	}
+	return b, nil
+}
+
+// Creates a baseline recommender that recommends items with the highest total value.
+func FitPopularSum[T Id, U Id](trainSet *Dataset[T, U]) (*Baseline[T, U], error) {
+	b, err := newBaseline(trainSet)
+	if err != nil {
+		return nil, err
+	}
+	for _, rating := range trainSet.data {
+		b.itemScores[b.itemMap[rating.itemId]] += rating.value
+	}
+	return b, nil
+}
+
+// Creates a baseline recommender that recommends items with the most ratings
+// among the last n ratings in the dataset.
+//
+// Ratings are ordered by timestamp, then by the order they were added.
+func FitRecentPopular[T Id, U Id](trainSet *Dataset[T, U], n int) (*Baseline[T, U], error) {
+	b, err := newBaseline(trainSet)
+	if err != nil {
+		return nil, err
+	}
+	indexes := make([]int, len(trainSet.data))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	slices.SortStableFunc(indexes, func(i, j int) int {
+		return trainSet.data[i].time.Compare(trainSet.data[j].time)
+	})
+	start := max(len(indexes)-n, 0)
+	for _, i := range indexes[start:] {
+		b.itemScores[b.itemMap[trainSet.data[i].itemId]] += 1.0
+	}
+	return b, nil
+}
+
+// Creates a baseline recommender that recommends random items.
+func FitRandom[T Id, U Id](trainSet *Dataset[T, U], options ...Option) (*Baseline[T, U], error) {
+	config := newConfig(options)
+
+	b, err := newBaseline(trainSet)
+	if err != nil {
+		return nil, err
+	}
+	b.random = true
+	b.seed = config.seed
+	return b, nil
+}
+
+func newBaseline[T Id, U Id](trainSet *Dataset[T, U]) (*Baseline[T, U], error) {
+	if trainSet.Len() == 0 {
+		return nil, errors.New("No training data")
+	}
+
+	b := &Baseline[T, U]{
+		userMap: make(map[T]int, 0),
+		itemMap: make(map[U]int, 0),
+	}
+
+	for _, rating := range trainSet.data {
+		u, ok := b.userMap[rating.userId]
+		if !ok {
+			u = len(b.userMap)
+			b.userMap[rating.userId] = u
+			b.userIds = append(b.userIds, rating.userId)
+			b.rated = append(b.rated, make(map[int]bool, 0))
+			b.userScores = append(b.userScores, 0.0)
+		}
+
+		i, ok := b.itemMap[rating.itemId]
+		if !ok {
+			i = len(b.itemMap)
+			b.itemMap[rating.itemId] = i
+			b.itemIds = append(b.itemIds, rating.itemId)
+		}
+
+		b.userScores[u] += 1.0
+		b.rated[u][i] = true
+	}
+
+	b.itemScores = make([]float32, len(b.itemIds))
+
+	return b, nil
+}
+
+// Returns recommendations for a user.
+func (b *Baseline[T, U]) UserRecs(userId T, count int) []Rec[U] {
+	u, ok := b.userMap[userId]
+	if !ok {
+		u = -1
+	}
+
+	var rated map[int]bool
+	if ok {
+		rated = b.rated[u]
+	}
+
+	scores := b.itemScores
+	if b.random {
+		scores = b.randomScores(u, len(b.itemIds))
+	}
+	return topRecs(b.itemIds, scores, func(j int) bool { return rated[j] }, count)
+}
+
+// Returns recommendations for an item.
+func (b *Baseline[T, U]) ItemRecs(itemId U, count int) []Rec[U] {
+	i, ok := b.itemMap[itemId]
+	if !ok {
+		i = -1
+	}
+
+	scores := b.itemScores
+	if b.random {
+		scores = b.randomScores(i, len(b.itemIds))
+	}
+	return topRecs(b.itemIds, scores, func(j int) bool { return j == i }, count)
+}
+
+// Returns similar users.
+func (b *Baseline[T, U]) SimilarUsers(userId T, count int) []Rec[T] {
+	u, ok := b.userMap[userId]
+	if !ok {
+		u = -1
+	}
+
+	scores := b.userScores
+	if b.random {
+		scores = b.randomScores(u, len(b.userIds))
+	}
+	return topRecs(b.userIds, scores, func(j int) bool { return j == u }, count)
+}
+
+// Returns the score for a specific user and item.
+func (b *Baseline[T, U]) Predict(userId T, itemId U) float32 {
+	i, ok := b.itemMap[itemId]
+	if !ok {
+		return 0.0
+	}
+
+	if b.random {
+		u, ok := b.userMap[userId]
+		if !ok {
+			u = -1
+		}
+		return randomScore(b.seed, u, i)
+	}
+
+	return b.itemScores[i]
+}
+
+// Returns user ids.
+func (b *Baseline[T, U]) UserIds() []T {
+	return b.userIds
+}
+
+// Returns item ids.
+func (b *Baseline[T, U]) ItemIds() []U {
+	return b.itemIds
+}
+
+func (b *Baseline[T, U]) randomScores(row int, n int) []float32 {
+	scores := make([]float32, n)
+	for j := range n {
+		scores[j] = randomScore(b.seed, row, j)
+	}
+	return scores
+}
+
+// deterministic so the same user always gets the same recommendations
+func randomScore(seed uint64, row int, col int) float32 {
+	// splitmix64
+	z := seed + uint64(row+1)*0x9e3779b97f4a7c15 + uint64(col)*0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z = z ^ (z >> 31)
+	return float32(z>>40) / (1 << 24)
+}
+
+func topRecs[T Id](ids []T, scores []float32, skip func(j int) bool, count int) []Rec[T] {
+	predictions := make([]Rec[int], 0, len(scores))
+	for j, score := range scores {
+		predictions = append(predictions, Rec[int]{Id: j, Score: score})
+	}
+	sort.SliceStable(predictions, func(j, k int) bool {
+		return predictions[j].Score > predictions[k].Score
+	})
+
+	recs := make([]Rec[T], 0, min(count, len(predictions)))
+	for _, prediction := range predictions {
+		if !skip(prediction.Id) {
+			recs = append(recs, Rec[T]{Id: ids[prediction.Id], Score: prediction.Score})
+			if len(recs) == count {
+				break
+			}
+		}
+	}
+	return recs
+}
@@ -0,0 +1,80 @@
+package disco_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ankane/disco-go"
+)
+
+func baselineData() *disco.Dataset[int, string] {
+	data := disco.NewDataset[int, string]()
+	data.Push(1, "A", 1.0)
+	data.Push(1, "B", 5.0)
+	data.Push(2, "A", 1.0)
+	data.Push(2, "C", 1.0)
+	data.Push(3, "A", 1.0)
+	data.Push(3, "C", 1.0)
+	data.Push(3, "D", 1.0)
+	return data
+}
+
+func TestPopular(t *testing.T) {
+	recommender, err := disco.FitPopular(baselineData())
+	assertNil(t, err)
+
+	assertDeepEqual(t, []string{"A", "C", "B"}, getIds(recommender.UserRecs(4, 3)))
+	assertDeepEqual(t, []string{"C", "D"}, getIds(recommender.UserRecs(1, 5)))
+	assertDeepEqual(t, []string{"A", "B"}, getIds(recommender.ItemRecs("C", 2)))
+	assertDeepEqual(t, []int{3, 2}, getIds(recommender.SimilarUsers(1, 5)))
+	assertEqual(t, 3.0, recommender.Predict(1, "A"))
+	assertEqual(t, 0.0, recommender.Predict(1, "E"))
+}
+
+func TestPopularSum(t *testing.T) {
+	recommender, err := disco.FitPopularSum(baselineData())
+	assertNil(t, err)
+
+	assertDeepEqual(t, []string{"B", "A", "C"}, getIds(recommender.UserRecs(4, 3)))
+}
+
+func TestRecentPopular(t *testing.T) {
+	recommender, err := disco.FitRecentPopular(baselineData(), 3)
+	assertNil(t, err)
+
+	assertDeepEqual(t, []string{"A", "C", "D", "B"}, getIds(recommender.UserRecs(4, 5)))
+}
+
+func TestRecentPopularTime(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := disco.NewDataset[int, string]()
+	data.PushAt(1, "A", 1.0, start.Add(2*time.Hour))
+	data.PushAt(2, "B", 1.0, start)
+	data.PushAt(3, "B", 1.0, start.Add(time.Hour))
+
+	recommender, err := disco.FitRecentPopular(data, 1)
+	assertNil(t, err)
+
+	assertDeepEqual(t, []string{"A", "B"}, getIds(recommender.UserRecs(4, 2)))
+}
+
+func TestRandom(t *testing.T) {
+	recommender, err := disco.FitRandom(baselineData(), disco.Seed(42))
+	assertNil(t, err)
+
+	recs := recommender.UserRecs(1, 5)
+	assertEqual(t, 2, len(recs))
+	assertNotContains(t, getIds(recs), "A")
+	assertDeepEqual(t, recs, recommender.UserRecs(1, 5))
+	assertEqual(t, recs[0].Score, recommender.Predict(1, recs[0].Id))
+
+	other, err := disco.FitRandom(baselineData(), disco.Seed(42))
+	assertNil(t, err)
+	assertDeepEqual(t, recs, other.UserRecs(1, 5))
+}
+
+func TestBaselineNoTrainingData(t *testing.T) {
+	data := disco.NewDataset[int, string]()
+	_, err := disco.FitPopular(data)
+	assertError(t, err, "No training data")
+}
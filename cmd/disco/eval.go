@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ankane/disco-go"
+)
+
+func eval(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("eval", stderr)
+	modelPath := fs.String("model", "", "model file (required)")
+	metrics := fs.String("metrics", "rmse,precision@10,recall@10,ndcg@10,map@10", "comma-separated metrics")
+	csvFlags := addCSVFlags(fs)
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("Expected one ratings file")
+	}
+	err = requireFlag("model", *modelPath)
+	if err != nil {
+		return err
+	}
+
+	var parsed []disco.Metric
+	for _, name := range strings.Split(*metrics, ",") {
+		metric, err := disco.ParseMetric(strings.TrimSpace(name))
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, metric)
+	}
+
+	model, err := loadModel(*modelPath)
+	if err != nil {
+		return err
+	}
+
+	validSet, err := csvFlags.read(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	for _, metric := range parsed {
+		fmt.Fprintf(stdout, "%s\t%s\n", metric, formatScore(disco.Evaluate(model, validSet, metric)))
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ankane/disco-go"
+)
+
+func export(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("export", stderr)
+	modelPath := fs.String("model", "", "model file (required)")
+	what := fs.String("what", "factors", "what to export: factors or neighbors")
+	users := fs.Bool("users", false, "export users instead of items")
+	count := fs.Int("count", 10, "number of neighbors")
+	format := fs.String("format", "tsv", "factor format: tsv, npy, npz, word2vec, projector, or projector-metadata")
+	output := fs.String("o", "", "output file (defaults to stdout)")
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	err = requirePositive(fs, "count", *count)
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() != 0 {
+		return fmt.Errorf("Unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	err = requireFlag("model", *modelPath)
+	if err != nil {
+		return err
+	}
+	if *what != "factors" && *what != "neighbors" {
+		return fmt.Errorf("Invalid export: %s", *what)
+	}
+	factorFormat, ok := factorFormats[*format]
+	if !ok && *format != "tsv" && *format != "npz" {
+		return fmt.Errorf("Invalid format: %s", *format)
+	}
+	if *what == "neighbors" && *format != "tsv" {
+		return errors.New("Neighbors only support tsv format")
+	}
+
+	model, err := loadModel(*modelPath)
+	if err != nil {
+		return err
+	}
+
+	write := func(w io.Writer) error {
+		if *what == "neighbors" {
+			return writeNeighbors(w, model, *users, *count)
+		}
+		switch {
+		case *format == "npz":
+			return model.WriteNpz(w)
+		case ok && *users:
+			return model.WriteUserFactors(w, factorFormat)
+		case ok:
+			return model.WriteItemFactors(w, factorFormat)
+		}
+		return writeFactors(w, model, *users)
+	}
+
+	if *output == "" {
+		return write(stdout)
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	err = write(file)
+	return errors.Join(err, file.Close())
+}
+
+var factorFormats = map[string]disco.FactorFormat{
+	"npy":                disco.FormatNpy,
+	"word2vec":           disco.FormatWord2Vec,
+	"projector":          disco.FormatProjector,
+	"projector-metadata": disco.FormatProjectorMetadata,
+}
+
+func exportIds(model *disco.Recommender[string, string], users bool) ([]string, func(id string) []float32) {
+	if users {
+		return model.UserIds(), model.UserFactors
+	}
+	return model.ItemIds(), model.ItemFactors
+}
+
+// id followed by factors on each line
+func writeFactors(out io.Writer, model *disco.Recommender[string, string], users bool) error {
+	w := bufio.NewWriter(out)
+	ids, factors := exportIds(model, users)
+	for _, id := range ids {
+		w.WriteString(id)
+		for _, v := range factors(id) {
+			w.WriteByte('\t')
+			w.WriteString(formatScore(v))
+		}
+		w.WriteByte('\n')
+	}
+	return w.Flush()
+}
+
+func writeNeighbors(out io.Writer, model *disco.Recommender[string, string], users bool, count int) error {
+	w := bufio.NewWriter(out)
+	ids, _ := exportIds(model, users)
+	for _, id := range ids {
+		if users {
+			writeRecs(w, id, model.SimilarUsers(id, count))
+		} else {
+			writeRecs(w, id, model.ItemRecs(id, count))
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,204 @@
+// Command disco trains, evaluates, and queries recommenders.
+//
+// Usage:
+//
+//	disco train [flags] ratings.csv
+//	disco eval [flags] ratings.csv
+//	disco recs [flags] user_id...
+//	disco similar [flags] id...
+//	disco export [flags]
+//
+// Models use string ids. Run a command with -h for its flags.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/ankane/disco-go"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+const usage = `Usage: disco <command> [flags]
+
+Commands:
+  train    train a model from a CSV file
+  eval     evaluate a model on a CSV file
+  recs     recommend items for users
+  similar  find similar items or users
+  export   export factors or neighbors
+`
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	var cmd func(args []string, stdout io.Writer, stderr io.Writer) error
+	switch args[0] {
+	case "train":
+		cmd = train
+	case "eval":
+		cmd = eval
+	case "recs":
+		cmd = recs
+	case "similar":
+		cmd = similar
+	case "export":
+		cmd = export
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "Unknown command: %s\n\n%s", args[0], usage)
+		return 2
+	}
+
+	err := cmd(args[1:], stdout, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if err != nil {
+		var usageErr usageError
+		if !errors.As(err, &usageErr) {
+			fmt.Fprintf(stderr, "Error: %s\n", err)
+		}
+		return 1
+	}
+	return 0
+}
+
+// already printed with usage
+type usageError struct {
+	error
+}
+
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("disco "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		return usageError{err}
+	}
+	return err
+}
+
+type csvFlags struct {
+	delimiter    string
+	header       bool
+	user         string
+	item         string
+	value        string
+	time         string
+	timeLayout   string
+	defaultValue float64
+}
+
+func addCSVFlags(fs *flag.FlagSet) *csvFlags {
+	f := &csvFlags{}
+	fs.StringVar(&f.delimiter, "delimiter", ",", "field delimiter (use tab for tabs)")
+	fs.BoolVar(&f.header, "header", false, "first row is a header")
+	fs.StringVar(&f.user, "user", "", "user id column index or name")
+	fs.StringVar(&f.item, "item", "", "item id column index or name")
+	fs.StringVar(&f.value, "value", "", "value column index or name")
+	fs.StringVar(&f.time, "time", "", "timestamp column index or name")
+	fs.StringVar(&f.timeLayout, "time-layout", "", "timestamp layout (defaults to Unix timestamps)")
+	fs.Float64Var(&f.defaultValue, "default-value", 0, "value for rows without a value")
+	return f
+}
+
+func (f *csvFlags) options() (disco.CSVOptions, error) {
+	options := disco.CSVOptions{
+		Header:       f.header,
+		User:         column(f.user),
+		Item:         column(f.item),
+		Value:        column(f.value),
+		Time:         column(f.time),
+		TimeLayout:   f.timeLayout,
+		DefaultValue: float32(f.defaultValue),
+	}
+
+	switch f.delimiter {
+	case "tab", `\t`:
+		options.Delimiter = '\t'
+	default:
+		runes := []rune(f.delimiter)
+		if len(runes) != 1 {
+			return options, fmt.Errorf("Invalid delimiter: %s", f.delimiter)
+		}
+		options.Delimiter = runes[0]
+	}
+
+	return options, nil
+}
+
+func (f *csvFlags) read(path string) (*disco.Dataset[string, string], error) {
+	options, err := f.options()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return disco.ReadCSV[string, string](file, options)
+}
+
+// indexes start at zero
+func column(s string) disco.Column {
+	if s == "" {
+		return disco.Column{}
+	}
+	i, err := strconv.Atoi(s)
+	if err == nil && i >= 0 {
+		return disco.ColumnIndex(i)
+	}
+	return disco.ColumnName(s)
+}
+
+func loadModel(path string) (*disco.Recommender[string, string], error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return disco.Load[string, string](file)
+}
+
+// printed with usage like flag parse errors
+func requirePositive(fs *flag.FlagSet, name string, value int) error {
+	if value < 1 {
+		err := fmt.Errorf("invalid value %d for flag -%s: must be positive", value, name)
+		fmt.Fprintln(fs.Output(), err)
+		fs.Usage()
+		return usageError{err}
+	}
+	return nil
+}
+
+func requireFlag(name string, value string) error {
+	if value == "" {
+		return fmt.Errorf("Missing -%s", name)
+	}
+	return nil
+}
+
+func formatScore(score float32) string {
+	return strconv.FormatFloat(float64(score), 'g', -1, 32)
+}
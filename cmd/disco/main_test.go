@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeRatings(t *testing.T, dir string) string {
+	var b strings.Builder
+	b.WriteString("user_id,item_id,rating\n")
+	for u := range 20 {
+		for i := range 10 {
+			if (u+i)%3 != 0 {
+				fmt.Fprintf(&b, "user%d,item%d,%d\n", u, i, 1+(u+i)%5)
+			}
+		}
+	}
+	path := filepath.Join(dir, "ratings.csv")
+	err := os.WriteFile(path, []byte(b.String()), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func runCommand(t *testing.T, args ...string) (int, string, string) {
+	var stdout, stderr strings.Builder
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCommands(t *testing.T) {
+	dir := t.TempDir()
+	ratings := writeRatings(t, dir)
+	model := filepath.Join(dir, "model.gob")
+
+	code, stdout, _ := runCommand(t, "train", "-header", "-factors", "4", "-seed", "42", "-o", model, ratings)
+	if code != 0 || stdout != "Trained on 133 ratings for 20 users and 10 items\n" {
+		t.Fatalf("train: %d %q", code, stdout)
+	}
+
+	code, stdout, _ = runCommand(t, "eval", "-header", "-model", model, "-metrics", "rmse,ndcg@5", ratings)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if code != 0 || len(lines) != 2 || !strings.HasPrefix(lines[0], "rmse\t") || !strings.HasPrefix(lines[1], "ndcg@5\t") {
+		t.Errorf("eval: %d %q", code, stdout)
+	}
+
+	code, stdout, _ = runCommand(t, "recs", "-model", model, "-count", "2", "user0")
+	lines = strings.Split(strings.TrimSpace(stdout), "\n")
+	if code != 0 || len(lines) != 2 || !strings.HasPrefix(lines[0], "user0\titem") {
+		t.Errorf("recs: %d %q", code, stdout)
+	}
+
+	code, stdout, _ = runCommand(t, "similar", "-model", model, "-count", "3", "item1")
+	if code != 0 || strings.Count(stdout, "\n") != 3 {
+		t.Errorf("similar: %d %q", code, stdout)
+	}
+
+	code, stdout, _ = runCommand(t, "similar", "-model", model, "-users", "-count", "3", "user1")
+	if code != 0 || !strings.HasPrefix(stdout, "user1\tuser") {
+		t.Errorf("similar users: %d %q", code, stdout)
+	}
+
+	code, stdout, _ = runCommand(t, "export", "-model", model)
+	lines = strings.Split(strings.TrimSpace(stdout), "\n")
+	if code != 0 || len(lines) != 10 || len(strings.Split(lines[0], "\t")) != 5 {
+		t.Errorf("export: %d %q", code, stdout)
+	}
+
+	output := filepath.Join(dir, "neighbors.tsv")
+	code, _, _ = runCommand(t, "export", "-model", model, "-what", "neighbors", "-users", "-count", "2", "-o", output)
+	contents, err := os.ReadFile(output)
+	if code != 0 || err != nil || strings.Count(string(contents), "\n") != 40 {
+		t.Errorf("export neighbors: %d %v", code, err)
+	}
+
+	code, stdout, _ = runCommand(t, "export", "-model", model, "-format", "word2vec", "-users")
+	if code != 0 || !strings.HasPrefix(stdout, "20 4\n") {
+		t.Errorf("export word2vec: %d %q", code, stdout)
+	}
+
+	output = filepath.Join(dir, "factors.npy")
+	code, _, _ = runCommand(t, "export", "-model", model, "-format", "npy", "-o", output)
+	contents, err = os.ReadFile(output)
+	if code != 0 || err != nil || !strings.HasPrefix(string(contents), "\x93NUMPY") {
+		t.Errorf("export npy: %d %v", code, err)
+	}
+
+	code, _, stderr := runCommand(t, "export", "-model", model, "-what", "neighbors", "-format", "npy")
+	if code == 0 || !strings.Contains(stderr, "Neighbors only support tsv format") {
+		t.Errorf("export neighbors npy: %d %q", code, stderr)
+	}
+}
+
+func TestTrainOptions(t *testing.T) {
+	dir := t.TempDir()
+	ratings := writeRatings(t, dir)
+	model := filepath.Join(dir, "model.gob")
+
+	features := filepath.Join(dir, "features.csv")
+	err := os.WriteFile(features, []byte("item1,genre:comedy\nitem2,genre:drama,0.5\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, _, stderr := runCommand(t, "train", "-header", "-implicit", "-log-confidence", "1", "-duplicates", "max", "-item-features", features, "-iterations", "2", "-verbose", "-o", model, ratings)
+	if code != 0 || strings.Count(stderr, "iteration=") != 2 {
+		t.Errorf("train: %d %q", code, stderr)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	dir := t.TempDir()
+	ratings := writeRatings(t, dir)
+
+	code, _, stderr := runCommand(t)
+	if code != 2 || !strings.HasPrefix(stderr, "Usage:") {
+		t.Errorf("no command: %d", code)
+	}
+
+	code, _, stderr = runCommand(t, "unknown")
+	if code != 2 || !strings.HasPrefix(stderr, "Unknown command: unknown") {
+		t.Errorf("unknown: %d", code)
+	}
+
+	code, _, stderr = runCommand(t, "train", "-header", ratings)
+	if code != 1 || stderr != "Error: Missing -o\n" {
+		t.Errorf("missing output: %d %q", code, stderr)
+	}
+
+	code, _, stderr = runCommand(t, "train", "-duplicates", "first", "-o", "model.gob", ratings)
+	if code != 1 || stderr != "Error: Invalid duplicate policy: first\n" {
+		t.Errorf("duplicates: %d %q", code, stderr)
+	}
+
+	code, _, stderr = runCommand(t, "eval", "-model", "model.gob", "-metrics", "mae", ratings)
+	if code != 1 || stderr != "Error: Invalid metric: mae\n" {
+		t.Errorf("metrics: %d %q", code, stderr)
+	}
+
+	code, _, stderr = runCommand(t, "recs", "-bad")
+	if code != 1 || !strings.Contains(stderr, "flag provided but not defined: -bad") {
+		t.Errorf("bad flag: %d %q", code, stderr)
+	}
+
+	for _, cmd := range []string{"recs", "similar", "export"} {
+		code, _, stderr = runCommand(t, cmd, "-count", "-1", "-model", "model.gob", "1")
+		if code != 1 || !strings.HasPrefix(stderr, "invalid value -1 for flag -count: must be positive\nUsage of disco "+cmd) {
+			t.Errorf("negative count: %s %d %q", cmd, code, stderr)
+		}
+	}
+
+	code, _, _ = runCommand(t, "recs", "-h")
+	if code != 0 {
+		t.Errorf("help: %d", code)
+	}
+}
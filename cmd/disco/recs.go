@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ankane/disco-go"
+)
+
+func recs(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("recs", stderr)
+	modelPath := fs.String("model", "", "model file (required)")
+	count := fs.Int("count", 10, "number of recommendations")
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	err = requirePositive(fs, "count", *count)
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("Expected at least one user id")
+	}
+	err = requireFlag("model", *modelPath)
+	if err != nil {
+		return err
+	}
+
+	model, err := loadModel(*modelPath)
+	if err != nil {
+		return err
+	}
+
+	for _, userId := range fs.Args() {
+		if model.UserFactors(userId) == nil {
+			return fmt.Errorf("User not found: %s", userId)
+		}
+		writeRecs(stdout, userId, model.UserRecs(userId, *count))
+	}
+	return nil
+}
+
+func similar(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("similar", stderr)
+	modelPath := fs.String("model", "", "model file (required)")
+	count := fs.Int("count", 10, "number of results")
+	users := fs.Bool("users", false, "find similar users instead of items")
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	err = requirePositive(fs, "count", *count)
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("Expected at least one id")
+	}
+	err = requireFlag("model", *modelPath)
+	if err != nil {
+		return err
+	}
+
+	model, err := loadModel(*modelPath)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range fs.Args() {
+		if *users {
+			if model.UserFactors(id) == nil {
+				return fmt.Errorf("User not found: %s", id)
+			}
+			writeRecs(stdout, id, model.SimilarUsers(id, *count))
+		} else {
+			if model.ItemFactors(id) == nil {
+				return fmt.Errorf("Item not found: %s", id)
+			}
+			writeRecs(stdout, id, model.ItemRecs(id, *count))
+		}
+	}
+	return nil
+}
+
+// one tab-separated line per recommendation
+func writeRecs(w io.Writer, id string, recs []disco.Rec[string]) {
+	for _, rec := range recs {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", id, rec.Id, formatScore(rec.Score))
+	}
+}
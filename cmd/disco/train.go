@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/ankane/disco-go"
+)
+
+func train(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("train", stderr)
+	output := fs.String("o", "", "model file to write (required)")
+	implicit := fs.Bool("implicit", false, "use implicit feedback")
+	validPath := fs.String("valid", "", "validation CSV file (explicit feedback only)")
+	factors := fs.Int("factors", 8, "number of factors")
+	iterations := fs.Int("iterations", 20, "number of iterations")
+	regularization := fs.Float64("regularization", 0, "regularization (defaults to 0.1 for explicit and 0.01 for implicit)")
+	learningRate := fs.Float64("learning-rate", 0.1, "learning rate")
+	alpha := fs.Float64("alpha", 40, "alpha for implicit feedback")
+	logConfidence := fs.Float64("log-confidence", 0, "use log confidence with epsilon")
+	negativeFeedback := fs.Bool("negative-feedback", false, "treat negative values as negative feedback")
+	halfLife := fs.Duration("half-life", 0, "half-life for time decay")
+	duplicates := fs.String("duplicates", "", "duplicate policy: sum, last, max, mean, or error")
+	seed := fs.Uint64("seed", 0, "random seed")
+	userFeatures := fs.String("user-features", "", "user features CSV file with id,feature[,value] rows")
+	itemFeatures := fs.String("item-features", "", "item features CSV file with id,feature[,value] rows")
+	verbose := fs.Bool("verbose", false, "print loss for each iteration")
+	csvFlags := addCSVFlags(fs)
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("Expected one ratings file")
+	}
+	err = requireFlag("o", *output)
+	if err != nil {
+		return err
+	}
+
+	// only pass options that are set to keep library defaults
+	var options []disco.Option
+	var visitErr error
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "factors":
+			options = append(options, disco.Factors(*factors))
+		case "iterations":
+			options = append(options, disco.Iterations(*iterations))
+		case "regularization":
+			options = append(options, disco.Regularization(float32(*regularization)))
+		case "learning-rate":
+			options = append(options, disco.LearningRate(float32(*learningRate)))
+		case "alpha":
+			options = append(options, disco.Alpha(float32(*alpha)))
+		case "log-confidence":
+			options = append(options, disco.LogConfidence(float32(*logConfidence)))
+		case "negative-feedback":
+			if *negativeFeedback {
+				options = append(options, disco.NegativeFeedback())
+			}
+		case "half-life":
+			options = append(options, disco.HalfLife(*halfLife))
+		case "duplicates":
+			policy, err := parseDuplicatePolicy(*duplicates)
+			if err != nil {
+				visitErr = err
+			}
+			options = append(options, disco.Duplicates(policy))
+		case "seed":
+			options = append(options, disco.Seed(*seed))
+		}
+	})
+	if visitErr != nil {
+		return visitErr
+	}
+
+	if *userFeatures != "" {
+		features, err := readFeatures(*userFeatures)
+		if err != nil {
+			return err
+		}
+		options = append(options, disco.UserFeatures(features))
+	}
+
+	if *itemFeatures != "" {
+		features, err := readFeatures(*itemFeatures)
+		if err != nil {
+			return err
+		}
+		options = append(options, disco.ItemFeatures(features))
+	}
+
+	if *verbose {
+		options = append(options, disco.Callback(func(info disco.FitInfo) {
+			fmt.Fprintf(stderr, "iteration=%d train_loss=%s valid_loss=%s\n", info.Iteration, formatLoss(info.TrainLoss), formatLoss(info.ValidLoss))
+		}))
+	}
+
+	data, err := csvFlags.read(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var recommender *disco.Recommender[string, string]
+	if *validPath != "" {
+		if *implicit {
+			return fmt.Errorf("-valid is not supported with -implicit")
+		}
+		validSet, err := csvFlags.read(*validPath)
+		if err != nil {
+			return err
+		}
+		recommender, err = disco.FitEvalExplicit(data, validSet, options...)
+		if err != nil {
+			return err
+		}
+	} else if *implicit {
+		recommender, err = disco.FitImplicit(data, options...)
+	} else {
+		recommender, err = disco.FitExplicit(data, options...)
+	}
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+
+	err = recommender.Save(file)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Trained on %d ratings for %d users and %d items\n", data.Len(), len(recommender.UserIds()), len(recommender.ItemIds()))
+	return nil
+}
+
+func parseDuplicatePolicy(s string) (disco.DuplicatePolicy, error) {
+	switch s {
+	case "sum":
+		return disco.DuplicateSum, nil
+	case "last":
+		return disco.DuplicateLast, nil
+	case "max":
+		return disco.DuplicateMax, nil
+	case "mean":
+		return disco.DuplicateMean, nil
+	case "error":
+		return disco.DuplicateError, nil
+	default:
+		return 0, fmt.Errorf("Invalid duplicate policy: %s", s)
+	}
+}
+
+func readFeatures(path string) (*disco.Features[string], error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	features := disco.NewFeatures[string]()
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return features, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(record) < 2 {
+			return nil, fmt.Errorf("%s line %d: missing feature", path, line)
+		}
+
+		var value float32 = 1.0
+		if len(record) > 2 && record[2] != "" {
+			v, err := strconv.ParseFloat(record[2], 32)
+			if err != nil {
+				return nil, fmt.Errorf("%s line %d: invalid value: %w", path, line, err)
+			}
+			value = float32(v)
+		}
+
+		features.Push(record[0], record[1], value)
+	}
+}
+
+func formatLoss(loss float32) string {
+	if math.IsNaN(float64(loss)) {
+		return "-"
+	}
+	return formatScore(loss)
+}
@@ -0,0 +1,178 @@
+package disco
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Adds an item or replaces its factors.
+//
+// Not safe to call concurrently with other methods.
+func (r *Recommender[T, U]) AddItem(itemId U, factors []float32) error {
+	if len(factors) != r.itemFactors.cols {
+		return fmt.Errorf("Factors must have length %d", r.itemFactors.cols)
+	}
+
+	i, ok := r.itemMap[itemId]
+	if ok {
+		copy(r.itemFactors.Row(i), factors)
+		r.itemNorms[i] = norm(factors)
+	} else {
+		r.itemMap[itemId] = len(r.itemIds)
+		r.itemIds = append(r.itemIds, itemId)
+		r.itemFactors.data = append(r.itemFactors.data, factors...)
+		r.itemFactors.rows++
+		r.itemNorms = append(r.itemNorms, norm(factors))
+	}
+
+	return nil
+}
+
+// Infers factors for a new item from similar items.
+func (r *Recommender[T, U]) InferItemFromItems(itemIds []U) []float32 {
+	res := make([]float32, r.itemFactors.cols)
+	n := 0
+	for _, itemId := range itemIds {
+		i, ok := r.itemMap[itemId]
+		if ok {
+			scaledAdd(res, 1.0, r.itemFactors.Row(i))
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	for d := range res {
+		res[d] /= float32(n)
+	}
+	return res
+}
+
+// Infers factors for a new item from features.
+func (r *Recommender[T, U]) InferItem(features map[string]float32) []float32 {
+	if r.itemFeatureFactors == nil {
+		return nil
+	}
+
+	row := make([]featureRow, 0, len(features))
+	for name, value := range features {
+		j, ok := r.itemFeatureMap[name]
+		if ok {
+			row = append(row, featureRow{index: j, value: value})
+		}
+	}
+	if len(row) == 0 {
+		return nil
+	}
+	normalizeFeatures(row)
+
+	res := make([]float32, r.itemFeatureFactors.cols)
+	combineFeatures(row, r.itemFeatureFactors, res)
+	return res
+}
+
+// Learns to infer item factors from features with ridge regression.
+func (r *Recommender[T, U]) FitItemFeatures(features *Features[U], options ...Option) error {
+	config := &config{}
+	for _, opt := range options {
+		opt(config)
+	}
+
+	var regularization float32
+	if config.regularization != nil {
+		regularization = *config.regularization
+	} else {
+		regularization = 0.1
+	}
+
+	featureMap := make(map[string]int, 0)
+	rows := make([][]featureRow, len(r.itemIds))
+	for _, f := range features.data {
+		i, ok := r.itemMap[f.id]
+		if !ok {
+			continue
+		}
+
+		j, ok := featureMap[f.name]
+		if !ok {
+			j = len(featureMap)
+			featureMap[f.name] = j
+		}
+
+		rows[i] = append(rows[i], featureRow{index: j, value: f.value})
+	}
+
+	if len(featureMap) == 0 {
+		return errors.New("No features for existing items")
+	}
+
+	for _, row := range rows {
+		normalizeFeatures(row)
+	}
+
+	// solve (XtX + lambda * I) w = XtY for each factor with conjugate gradient
+	factors := r.itemFactors.cols
+	n := len(featureMap)
+	weights := newMatrix(n, factors)
+
+	xtx := func(v []float32) []float32 {
+		res := make([]float32, n)
+		for _, row := range rows {
+			var xv float32 = 0.0
+			for _, f := range row {
+				xv += f.value * v[f.index]
+			}
+			for _, f := range row {
+				res[f.index] += f.value * xv
+			}
+		}
+		scaledAdd(res, regularization, v)
+		return res
+	}
+
+	w := make([]float32, n)
+	for d := range factors {
+		// r = XtY - A w with w = 0
+		res := make([]float32, n)
+		for i, row := range rows {
+			y := r.itemFactors.data[i*factors+d]
+			for _, f := range row {
+				res[f.index] += f.value * y
+			}
+		}
+
+		for k := range w {
+			w[k] = 0.0
+		}
+		p := make([]float32, n)
+		copy(p, res)
+		rsold := dot(res, res)
+
+		for range min(n, 100) {
+			if rsold < 1e-20 {
+				break
+			}
+
+			ap := xtx(p)
+			alpha := rsold / dot(p, ap)
+			scaledAdd(w, alpha, p)
+			scaledAdd(res, -alpha, ap)
+			rsnew := dot(res, res)
+
+			rs := rsnew / rsold
+			for k := range p {
+				p[k] = res[k] + rs*p[k]
+			}
+			rsold = rsnew
+		}
+
+		for k := range n {
+			weights.data[k*factors+d] = w[k]
+		}
+	}
+
+	r.itemFeatureMap = featureMap
+	r.itemFeatureFactors = weights
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package disco_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ankane/disco-go"
+)
+
+func TestAddItem(t *testing.T) {
+	data := disco.NewDataset[int, string]()
+	data.Push(1, "A", 1.0)
+	data.Push(1, "B", 1.0)
+	data.Push(2, "B", 1.0)
+
+	recommender, err := disco.FitExplicit(data, disco.Factors(20))
+	assertNil(t, err)
+
+	factors := recommender.InferItemFromItems([]string{"A", "D"})
+	assertDeepEqual(t, recommender.ItemFactors("A"), factors)
+	assertDeepEqual(t, nil, recommender.InferItemFromItems([]string{"D"}))
+
+	assertEqual(t, 1, len(recommender.ItemRecs("A", 5)))
+	err = recommender.AddItem("C", factors)
+	assertNil(t, err)
+
+	assertDeepEqual(t, []string{"A", "B", "C"}, recommender.ItemIds())
+	assertDeepEqual(t, factors, recommender.ItemFactors("C"))
+	assertEqual(t, "C", recommender.ItemRecs("A", 5)[0].Id)
+	assertInDelta(t, 1.0, recommender.ItemRecs("A", 5)[0].Score, 0.0001)
+	assertContains(t, getIds(recommender.UserRecs(2, 5)), "C")
+
+	// replacing factors updates the norm
+	scaled := make([]float32, len(factors))
+	for i, v := range factors {
+		scaled[i] = 2 * v
+	}
+	err = recommender.AddItem("B", scaled)
+	assertNil(t, err)
+	for _, rec := range recommender.ItemRecs("A", 5) {
+		assertInDelta(t, 1.0, rec.Score, 0.0001)
+	}
+
+	err = recommender.AddItem("D", []float32{1.0})
+	assertError(t, err, "Factors must have length 20")
+}
+
+func TestFitItemFeatures(t *testing.T) {
+	data, features := hybridData()
+
+	recommender, err := disco.FitImplicit(data, disco.Seed(42))
+	assertNil(t, err)
+	assertDeepEqual(t, nil, recommender.InferItem(map[string]float32{"genre:comedy": 1.0}))
+
+	err = recommender.FitItemFeatures(features)
+	assertNil(t, err)
+	assertDeepEqual(t, nil, recommender.InferItem(map[string]float32{"genre:horror": 1.0}))
+
+	err = recommender.AddItem("new comedy", recommender.InferItem(map[string]float32{"genre:comedy": 1.0}))
+	assertNil(t, err)
+
+	for _, id := range getIds(recommender.ItemRecs("new comedy", 5)) {
+		assertTrue(t, strings.HasPrefix(id, "comedy"))
+	}
+}
+
+func TestFitItemFeaturesNoFeatures(t *testing.T) {
+	data, _ := hybridData()
+
+	recommender, err := disco.FitImplicit(data)
+	assertNil(t, err)
+
+	err = recommender.FitItemFeatures(disco.NewFeatures[string]())
+	assertError(t, err, "No features for existing items")
+}
@@ -1,16 +1,44 @@
 package disco
 
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
 // A recommender option.
 type Option func(*config)
 
 type config struct {
-	factors        int
-	iterations     int
-	regularization *float32
-	learningRate   float32
-	alpha          float32
-	callback       func(info FitInfo)
-	seed           uint64
+	factors          int
+	iterations       int
+	regularization   *float32
+	learningRate     float32
+	alpha            float32
+	confidence       func(value float32, alpha float32) float32
+	epsilon          *float32
+	negativeFeedback bool
+	duplicates       *DuplicatePolicy
+	decay            func(age time.Duration) float32
+	callback         func(info FitInfo)
+	seed             uint64
+	userFeatures     any
+	itemFeatures     any
+}
+
+func newConfig(options []Option) *config {
+	config := &config{
+		factors:      8,
+		iterations:   20,
+		learningRate: 0.1,
+		alpha:        40.0,
+		confidence:   linearConfidence,
+		seed:         rand.Uint64(),
+	}
+	for _, opt := range options {
+		opt(config)
+	}
+	return config
 }
 
 // Sets the number of factors.
@@ -48,6 +76,75 @@ func Alpha(alpha float32) Option {
 	}
 }
 
+// Uses linear confidence for implicit feedback, which is the default.
+//
+// The confidence is 1 + alpha * value.
+func LinearConfidence() Option {
+	return func(c *config) {
+		c.confidence = linearConfidence
+		c.epsilon = nil
+	}
+}
+
+// Uses logarithmic confidence for implicit feedback.
+//
+// The confidence is 1 + alpha * log(1 + value / epsilon). Epsilon must be positive.
+func LogConfidence(epsilon float32) Option {
+	return func(c *config) {
+		c.confidence = func(value float32, alpha float32) float32 {
+			return 1.0 + alpha*float32(math.Log1p(float64(value/epsilon)))
+		}
+		c.epsilon = &epsilon
+	}
+}
+
+// Sets a custom confidence function for implicit feedback.
+//
+// Confidences must be finite and non-negative.
+func Confidence(confidence func(value float32) float32) Option {
+	return func(c *config) {
+		c.confidence = func(value float32, alpha float32) float32 {
+			return confidence(value)
+		}
+		c.epsilon = nil
+	}
+}
+
+func linearConfidence(value float32, alpha float32) float32 {
+	return 1.0 + alpha*value
+}
+
+// Treats negative values as negative feedback for implicit feedback.
+//
+// Items with negative values have a preference of zero
+// and a confidence based on the magnitude of the value.
+func NegativeFeedback() Option {
+	return func(c *config) {
+		c.negativeFeedback = true
+	}
+}
+
+// Sets the half-life for decaying implicit feedback over time.
+func HalfLife(halfLife time.Duration) Option {
+	return Decay(func(age time.Duration) float32 {
+		return float32(math.Exp2(-age.Seconds() / halfLife.Seconds()))
+	})
+}
+
+// Sets a custom function for decaying implicit feedback over time.
+func Decay(decay func(age time.Duration) float32) Option {
+	return func(c *config) {
+		c.decay = decay
+	}
+}
+
+// Sets the policy for combining duplicate ratings before training.
+func Duplicates(policy DuplicatePolicy) Option {
+	return func(c *config) {
+		c.duplicates = &policy
+	}
+}
+
 // Sets the callback for each iteration.
 func Callback(callback func(info FitInfo)) Option {
 	return func(c *config) {
@@ -61,3 +158,17 @@ func Seed(seed uint64) Option {
 		c.seed = seed
 	}
 }
+
+// Sets user features.
+func UserFeatures[T Id](features *Features[T]) Option {
+	return func(c *config) {
+		c.userFeatures = features
+	}
+}
+
+// Sets item features.
+func ItemFeatures[U Id](features *Features[U]) Option {
+	return func(c *config) {
+		c.itemFeatures = features
+	}
+}
@@ -0,0 +1,167 @@
+package disco
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"sync"
+)
+
+// A training and validation set for cross-validation.
+type Fold[T Id, U Id] struct {
+	TrainSet *Dataset[T, U]
+	ValidSet *Dataset[T, U]
+}
+
+// The results of an evaluation.
+type EvalResult struct {
+	// The score for each fold.
+	Scores []float32
+	// The mean score.
+	Mean float32
+	// The standard deviation of scores.
+	StdDev float32
+}
+
+// Splits the dataset into k folds.
+//
+// Returns no folds if k is less than 2, and k is capped at the number of ratings.
+func (d *Dataset[T, U]) KFold(k int) []Fold[T, U] {
+	if k < 2 {
+		return []Fold[T, U]{}
+	}
+	k = min(k, len(d.data))
+
+	indexes := rand.Perm(len(d.data))
+	assignments := make([]int, len(d.data))
+	for j, i := range indexes {
+		assignments[i] = j % k
+	}
+	return d.folds(k, assignments)
+}
+
+// Splits the ratings for each user into k folds.
+//
+// Users always have at least one rating in the training set.
+// Returns no folds if k is less than 2.
+func (d *Dataset[T, U]) KFoldPerUser(k int) []Fold[T, U] {
+	if k < 2 {
+		return []Fold[T, U]{}
+	}
+
+	userIndexes := make(map[T][]int)
+	for i, rating := range d.data {
+		userIndexes[rating.userId] = append(userIndexes[rating.userId], i)
+	}
+
+	assignments := make([]int, len(d.data))
+	for _, indexes := range userIndexes {
+		shuffleIndexes(indexes)
+		for j, i := range indexes {
+			if len(indexes) == 1 {
+				// only in training set
+				assignments[i] = -1
+			} else {
+				assignments[i] = j % k
+			}
+		}
+	}
+	return d.folds(k, assignments)
+}
+
+func (d *Dataset[T, U]) folds(k int, assignments []int) []Fold[T, U] {
+	folds := make([]Fold[T, U], 0, k)
+	for f := range k {
+		valid := make([]bool, len(d.data))
+		for i, a := range assignments {
+			valid[i] = a == f
+		}
+		trainSet, validSet := d.partition(valid)
+		folds = append(folds, Fold[T, U]{TrainSet: trainSet, ValidSet: validSet})
+	}
+	return folds
+}
+
+// Performs cross-validation with explicit feedback.
+//
+// Folds are trained in parallel.
+func CrossValidateExplicit[T Id, U Id](folds []Fold[T, U], metric Metric, options ...Option) (EvalResult, error) {
+	return crossValidate(folds, false, metric, true, options...)
+}
+
+// Performs cross-validation with implicit feedback.
+//
+// Folds are trained in parallel.
+func CrossValidateImplicit[T Id, U Id](folds []Fold[T, U], metric Metric, options ...Option) (EvalResult, error) {
+	return crossValidate(folds, true, metric, true, options...)
+}
+
+// hyperparameter search trains candidates in parallel, so it evaluates folds sequentially
+func crossValidate[T Id, U Id](folds []Fold[T, U], implicit bool, metric Metric, parallel bool, options ...Option) (EvalResult, error) {
+	if len(folds) == 0 {
+		return EvalResult{}, errors.New("No folds")
+	}
+
+	for _, fold := range folds {
+		if fold.ValidSet.Len() == 0 {
+			return EvalResult{}, errors.New("Empty validation set")
+		}
+	}
+
+	if !metric.valid() {
+		return EvalResult{}, errors.New("Invalid metric")
+	}
+
+	scores := make([]float32, len(folds))
+	errs := make([]error, len(folds))
+
+	evalFold := func(i int) {
+		recommender, err := fit(folds[i].TrainSet, nil, implicit, options...)
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		scores[i] = Evaluate(recommender, folds[i].ValidSet, metric)
+	}
+
+	if parallel {
+		var wg sync.WaitGroup
+		for i := range folds {
+			wg.Go(func() {
+				evalFold(i)
+			})
+		}
+		wg.Wait()
+	} else {
+		for i := range folds {
+			evalFold(i)
+		}
+	}
+
+	err := errors.Join(errs...)
+	if err != nil {
+		return EvalResult{}, err
+	}
+
+	return newEvalResult(scores), nil
+}
+
+func newEvalResult(scores []float32) EvalResult {
+	var mean float64 = 0.0
+	for _, score := range scores {
+		mean += float64(score)
+	}
+	mean /= float64(len(scores))
+
+	var variance float64 = 0.0
+	for _, score := range scores {
+		variance += (float64(score) - mean) * (float64(score) - mean)
+	}
+	variance /= float64(len(scores))
+
+	return EvalResult{
+		Scores: scores,
+		Mean:   float32(mean),
+		StdDev: float32(math.Sqrt(variance)),
+	}
+}
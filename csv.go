@@ -0,0 +1,240 @@
+package disco
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A column in a CSV file.
+type Column struct {
+	name string
+	// one-based so the zero value is unset
+	index int
+}
+
+// Returns a column with the specified index, starting at zero.
+func ColumnIndex(index int) Column {
+	return Column{index: index + 1}
+}
+
+// Returns a column with the specified header name.
+func ColumnName(name string) Column {
+	return Column{name: name}
+}
+
+// Options for reading CSV files.
+type CSVOptions struct {
+	// The field delimiter (defaults to a comma).
+	Delimiter rune
+	// Whether the first row is a header.
+	Header bool
+	// The user id column (defaults to the first column).
+	User Column
+	// The item id column (defaults to the second column).
+	Item Column
+	// The value column (defaults to the third column if the user and item columns are not set).
+	Value Column
+	// The timestamp column (defaults to none).
+	Time Column
+	// The layout for parsing timestamps (defaults to Unix timestamps in seconds).
+	TimeLayout string
+	// The value for ratings without a value.
+	DefaultValue float32
+}
+
+// Reads a dataset from a CSV file.
+func ReadCSV[T Id, U Id](r io.Reader, options CSVOptions) (*Dataset[T, U], error) {
+	reader := csv.NewReader(r)
+	if options.Delimiter != 0 {
+		reader.Comma = options.Delimiter
+	}
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	var header []string
+	if options.Header {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return NewDataset[T, U](), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		header = slices.Clone(record)
+	}
+
+	userIndex, err := columnIndex(options.User, header, 0)
+	if err != nil {
+		return nil, err
+	}
+	itemIndex, err := columnIndex(options.Item, header, 1)
+	if err != nil {
+		return nil, err
+	}
+	defaultValueIndex := -1
+	if options.User == (Column{}) && options.Item == (Column{}) {
+		defaultValueIndex = 2
+	}
+	valueIndex, err := columnIndex(options.Value, header, defaultValueIndex)
+	if err != nil {
+		return nil, err
+	}
+	timeIndex, err := columnIndex(options.Time, header, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	data := NewDataset[T, U]()
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if userIndex >= len(record) || itemIndex >= len(record) {
+			return nil, fmt.Errorf("Line %d: missing user or item", line)
+		}
+
+		userId, err := ParseId[T](record[userIndex])
+		if err != nil {
+			return nil, fmt.Errorf("Line %d: invalid user id: %w", line, err)
+		}
+
+		itemId, err := ParseId[U](record[itemIndex])
+		if err != nil {
+			return nil, fmt.Errorf("Line %d: invalid item id: %w", line, err)
+		}
+
+		value := options.DefaultValue
+		if valueIndex >= 0 && valueIndex < len(record) && record[valueIndex] != "" {
+			v, err := strconv.ParseFloat(strings.TrimSpace(record[valueIndex]), 32)
+			if err != nil {
+				return nil, fmt.Errorf("Line %d: invalid value: %w", line, err)
+			}
+			value = float32(v)
+		}
+
+		var t time.Time
+		if timeIndex >= 0 && timeIndex < len(record) && record[timeIndex] != "" {
+			t, err = parseTime(strings.TrimSpace(record[timeIndex]), options.TimeLayout)
+			if err != nil {
+				return nil, fmt.Errorf("Line %d: invalid time: %w", line, err)
+			}
+		}
+
+		data.PushAt(userId, itemId, value, t)
+	}
+
+	return data, nil
+}
+
+func columnIndex(column Column, header []string, defaultIndex int) (int, error) {
+	if column.name != "" {
+		if header == nil {
+			return 0, errors.New("Column names require a header")
+		}
+		i := slices.Index(header, column.name)
+		if i < 0 {
+			return 0, fmt.Errorf("Column not found: %s", column.name)
+		}
+		return i, nil
+	}
+	if column.index > 0 {
+		return column.index - 1, nil
+	}
+	return defaultIndex, nil
+}
+
+func parseTime(s string, layout string) (time.Time, error) {
+	if layout == "" {
+		seconds, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(seconds, 0), nil
+	}
+	return time.Parse(layout, s)
+}
+
+// Converts a string to an id.
+func ParseId[T Id](s string) (T, error) {
+	var id T
+	var err error
+	switch p := any(&id).(type) {
+	case *string:
+		*p = s
+	case *int:
+		*p, err = parseInt[int](s, strconv.IntSize)
+	case *int8:
+		*p, err = parseInt[int8](s, 8)
+	case *int16:
+		*p, err = parseInt[int16](s, 16)
+	case *int32:
+		*p, err = parseInt[int32](s, 32)
+	case *int64:
+		*p, err = parseInt[int64](s, 64)
+	case *uint:
+		*p, err = parseUint[uint](s, strconv.IntSize)
+	case *uint8:
+		*p, err = parseUint[uint8](s, 8)
+	case *uint16:
+		*p, err = parseUint[uint16](s, 16)
+	case *uint32:
+		*p, err = parseUint[uint32](s, 32)
+	case *uint64:
+		*p, err = parseUint[uint64](s, 64)
+	}
+	return id, err
+}
+
+func parseInt[T int | int8 | int16 | int32 | int64](s string, bitSize int) (T, error) {
+	v, err := strconv.ParseInt(strings.TrimSpace(s), 10, bitSize)
+	return T(v), err
+}
+
+func parseUint[T uint | uint8 | uint16 | uint32 | uint64](s string, bitSize int) (T, error) {
+	v, err := strconv.ParseUint(strings.TrimSpace(s), 10, bitSize)
+	return T(v), err
+}
+
+// Writes the dataset to a CSV file.
+//
+// The file has a header with user_id, item_id, value, and time columns,
+// with times in RFC 3339 format.
+func (d *Dataset[T, U]) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"user_id", "item_id", "value", "time"})
+	if err != nil {
+		return err
+	}
+
+	record := make([]string, 4)
+	for _, rating := range d.data {
+		record[0] = fmt.Sprint(rating.userId)
+		record[1] = fmt.Sprint(rating.itemId)
+		record[2] = strconv.FormatFloat(float64(rating.value), 'g', -1, 32)
+		record[3] = ""
+		if !rating.time.IsZero() {
+			record[3] = rating.time.Format(time.RFC3339Nano)
+		}
+		err := writer.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
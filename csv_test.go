@@ -0,0 +1,107 @@
+package disco_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ankane/disco-go"
+)
+
+func TestReadCSV(t *testing.T) {
+	input := "1,A,5\n1,B,3.5\n2,A,4\n"
+	data, err := disco.ReadCSV[int, string](strings.NewReader(input), disco.CSVOptions{})
+	assertNil(t, err)
+	assertEqual(t, 3, data.Len())
+
+	baseline, err := disco.FitPopularSum(data)
+	assertNil(t, err)
+	assertDeepEqual(t, []int{1, 2}, baseline.UserIds())
+	assertEqual(t, 9.0, baseline.Predict(1, "A"))
+}
+
+func TestReadCSVHeader(t *testing.T) {
+	input := "timestamp\titem\tuser\n1735689600\t10\tx\n1735693200\t20\ty\n"
+	options := disco.CSVOptions{
+		Delimiter:    '\t',
+		Header:       true,
+		User:         disco.ColumnName("user"),
+		Item:         disco.ColumnName("item"),
+		Time:         disco.ColumnIndex(0),
+		DefaultValue: 1.0,
+	}
+	data, err := disco.ReadCSV[string, uint16](strings.NewReader(input), options)
+	assertNil(t, err)
+	assertEqual(t, 2, data.Len())
+
+	trainSet, validSet := data.SplitByTime(time.Unix(1735690000, 0))
+	assertEqual(t, 1, trainSet.Len())
+	assertEqual(t, 1, validSet.Len())
+
+	baseline, err := disco.FitPopularSum(data)
+	assertNil(t, err)
+	assertDeepEqual(t, []string{"x", "y"}, baseline.UserIds())
+	assertDeepEqual(t, []uint16{10, 20}, baseline.ItemIds())
+	assertEqual(t, 1.0, baseline.Predict("x", 10))
+}
+
+func TestReadCSVTimeLayout(t *testing.T) {
+	input := "1,A,1,2025-01-01\n"
+	options := disco.CSVOptions{Time: disco.ColumnIndex(3), TimeLayout: time.DateOnly}
+	data, err := disco.ReadCSV[int, string](strings.NewReader(input), options)
+	assertNil(t, err)
+
+	trainSet, _ := data.SplitByTime(time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC))
+	assertEqual(t, 1, trainSet.Len())
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	_, err := disco.ReadCSV[int, string](strings.NewReader("1,A,1\nb,B,1\n"), disco.CSVOptions{})
+	assertError(t, err, `Line 2: invalid user id: strconv.ParseInt: parsing "b": invalid syntax`)
+
+	_, err = disco.ReadCSV[int, string](strings.NewReader("1,A,1\n1,B,x\n"), disco.CSVOptions{})
+	assertError(t, err, `Line 2: invalid value: strconv.ParseFloat: parsing "x": invalid syntax`)
+
+	_, err = disco.ReadCSV[int8, string](strings.NewReader("1000,A,1\n"), disco.CSVOptions{})
+	assertError(t, err, `Line 1: invalid user id: strconv.ParseInt: parsing "1000": value out of range`)
+
+	_, err = disco.ReadCSV[int, string](strings.NewReader("1\n"), disco.CSVOptions{})
+	assertError(t, err, "Line 1: missing user or item")
+
+	_, err = disco.ReadCSV[int, string](strings.NewReader("user,item\n"), disco.CSVOptions{Header: true, User: disco.ColumnName("user_id")})
+	assertError(t, err, "Column not found: user_id")
+
+	_, err = disco.ReadCSV[int, string](strings.NewReader("1,A\n"), disco.CSVOptions{User: disco.ColumnName("user")})
+	assertError(t, err, "Column names require a header")
+}
+
+func TestParseId(t *testing.T) {
+	id, err := disco.ParseId[uint64]("18446744073709551615")
+	assertNil(t, err)
+	assertEqual(t, uint64(18446744073709551615), id)
+
+	_, err = disco.ParseId[uint]("-1")
+	assertError(t, err, `strconv.ParseUint: parsing "-1": invalid syntax`)
+}
+
+func TestWriteCSV(t *testing.T) {
+	data := disco.NewDataset[int, string]()
+	data.Push(1, "A", 5.0)
+	data.PushAt(1, "B, C", 3.5, time.Date(2025, 1, 1, 12, 30, 0, 500, time.UTC))
+
+	var b strings.Builder
+	err := data.WriteCSV(&b)
+	assertNil(t, err)
+
+	expected := "user_id,item_id,value,time\n1,A,5,\n1,\"B, C\",3.5,2025-01-01T12:30:00.0000005Z\n"
+	assertEqual(t, expected, b.String())
+
+	options := disco.CSVOptions{Header: true, Time: disco.ColumnName("time"), TimeLayout: time.RFC3339Nano}
+	data2, err := disco.ReadCSV[int, string](strings.NewReader(b.String()), options)
+	assertNil(t, err)
+
+	var b2 strings.Builder
+	err = data2.WriteCSV(&b2)
+	assertNil(t, err)
+	assertEqual(t, expected, b2.String())
+}
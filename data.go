@@ -1,93 +1,417 @@
 package disco
 
 import (
+	"archive/zip"
 	"bufio"
 	"crypto/sha256"
+	"encoding/csv"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"log/slog"
+	"math/rand/v2"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// A dataset loading option.
+type LoadOption func(*loadConfig)
+
+type loadConfig struct {
+	fsys     fs.FS
+	cacheDir string
+	client   *http.Client
+	offline  bool
+	logger   *slog.Logger
+}
+
+// Loads the dataset from a file system instead of downloading it.
+func FromFS(fsys fs.FS) LoadOption {
+	return func(c *loadConfig) {
+		c.fsys = fsys
+	}
+}
+
+// Loads the dataset from a directory instead of downloading it.
+func FromDir(path string) LoadOption {
+	return FromFS(os.DirFS(path))
+}
+
+// Sets the directory for downloaded files (defaults to disco in the user cache directory).
+func CacheDir(path string) LoadOption {
+	return func(c *loadConfig) {
+		c.cacheDir = path
+	}
+}
+
+// Sets the HTTP client for downloads (defaults to http.DefaultClient).
+func HTTPClient(client *http.Client) LoadOption {
+	return func(c *loadConfig) {
+		if client == nil {
+			client = http.DefaultClient
+		}
+		c.client = client
+	}
+}
+
+// Disables downloads, so files must already be in the cache directory.
+func Offline() LoadOption {
+	return func(c *loadConfig) {
+		c.offline = true
+	}
+}
+
+// Sets the logger for progress messages (defaults to slog.Default()).
+// A nil logger discards messages.
+func Logger(logger *slog.Logger) LoadOption {
+	return func(c *loadConfig) {
+		if logger == nil {
+			logger = slog.New(slog.DiscardHandler)
+		}
+		c.logger = logger
+	}
+}
+
+func newLoadConfig(options []LoadOption) *loadConfig {
+	config := &loadConfig{client: http.DefaultClient, logger: slog.Default()}
+	for _, opt := range options {
+		opt(config)
+	}
+	return config
+}
+
 // Loads the MovieLens 100K dataset.
-func LoadMovieLens() (*Dataset[int, string], error) {
+func LoadMovieLens(options ...LoadOption) (*Dataset[int, string], error) {
+	config := newLoadConfig(options)
+	fsys, cleanup, err := loadFS(config, func(root *os.Root) (fs.FS, func() error, error) {
+		return config.downloadMovieLens(root, "u.item", "u.data")
+	})
+	if err != nil {
+		return NewDataset[int, string](), err
+	}
+	defer cleanup()
+
+	movies, err := readMovies(fsys, "u.item", "|")
+	if err != nil {
+		return NewDataset[int, string](), err
+	}
+
+	data := NewDataset[int, string]()
+	data.Grow(100000)
+	err = readRatings(fsys, "u.data", "\t", movies, data)
+	return data, err
+}
+
+// pinned SHA-256 checksums for downloads
+var checksums = map[string]string{
+	"ml-100k/u.item": "553841ebc7de3a0fd0d6b62a204ea30c1e651aacfb2814c7a6584ac52f2c5701",
+	"ml-100k/u.data": "06416e597f82b7342361e41163890c81036900f418ad91315590814211dca490",
+}
+
+func (c *loadConfig) downloadMovieLens(root *os.Root, names ...string) (fs.FS, func() error, error) {
+	for _, name := range names {
+		err := c.downloadFile(root, "ml-100k/"+name, "https://files.grouplens.org/datasets/movielens/ml-100k/"+name)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	fsys, err := fs.Sub(root.FS(), "ml-100k")
+	return fsys, nil, err
+}
+
+// Loads the MovieLens 1M dataset.
+func LoadMovieLens1M(options ...LoadOption) (*Dataset[int, string], error) {
+	config := newLoadConfig(options)
+	fsys, cleanup, err := loadFS(config, func(root *os.Root) (fs.FS, func() error, error) {
+		return config.downloadZip(root, "ml-1m.zip", "https://files.grouplens.org/datasets/movielens/ml-1m.zip", "ml-1m")
+	})
+	if err != nil {
+		return NewDataset[int, string](), err
+	}
+	defer cleanup()
+
+	movies, err := readMovies(fsys, "movies.dat", "::")
+	if err != nil {
+		return NewDataset[int, string](), err
+	}
+
 	data := NewDataset[int, string]()
+	data.Grow(1000209)
+	err = readRatings(fsys, "ratings.dat", "::", movies, data)
+	return data, err
+}
+
+// Loads the MovieLens 20M dataset.
+func LoadMovieLens20M(options ...LoadOption) (*Dataset[int, string], error) {
+	return loadMovieLensCsv("ml-20m", options)
+}
 
-	root, err := cacheDir()
+// Loads the MovieLens 25M dataset.
+func LoadMovieLens25M(options ...LoadOption) (*Dataset[int, string], error) {
+	return loadMovieLensCsv("ml-25m", options)
+}
+
+func loadMovieLensCsv(name string, options []LoadOption) (*Dataset[int, string], error) {
+	config := newLoadConfig(options)
+	fsys, cleanup, err := loadFS(config, func(root *os.Root) (fs.FS, func() error, error) {
+		return config.downloadZip(root, name+".zip", "https://files.grouplens.org/datasets/movielens/"+name+".zip", name)
+	})
 	if err != nil {
-		return data, err
+		return NewDataset[int, string](), err
 	}
+	defer cleanup()
 
-	itemPath, err := downloadFile(
-		root,
-		"ml-100k/u.item",
-		"https://files.grouplens.org/datasets/movielens/ml-100k/u.item",
-		"553841ebc7de3a0fd0d6b62a204ea30c1e651aacfb2814c7a6584ac52f2c5701",
-	)
+	movies := make(map[string]string)
+	err = readCsvFile(fsys, "movies.csv", ',', func(record []string) error {
+		if len(record) < 2 {
+			return errors.New("Invalid movies.csv")
+		}
+		movies[record[0]] = record[1]
+		return nil
+	})
 	if err != nil {
-		return data, err
+		return NewDataset[int, string](), err
 	}
 
-	dataPath, err := downloadFile(
-		root,
-		"ml-100k/u.data",
-		"https://files.grouplens.org/datasets/movielens/ml-100k/u.data",
-		"06416e597f82b7342361e41163890c81036900f418ad91315590814211dca490",
-	)
+	data := NewDataset[int, string]()
+	err = readCsvFile(fsys, "ratings.csv", ',', func(record []string) error {
+		if len(record) < 4 {
+			return errors.New("Invalid ratings.csv")
+		}
+		return pushRating(data, record[0], movies[record[1]], record[2], record[3])
+	})
+	return data, err
+}
+
+// Loads the Last.fm dataset from HetRec 2011.
+//
+// Values are play counts.
+func LoadLastFm(options ...LoadOption) (*Dataset[int, string], error) {
+	config := newLoadConfig(options)
+	fsys, cleanup, err := loadFS(config, func(root *os.Root) (fs.FS, func() error, error) {
+		// files are at the top level of the zip
+		return config.downloadZip(root, "hetrec2011-lastfm-2k.zip", "https://files.grouplens.org/datasets/hetrec2011/hetrec2011-lastfm-2k.zip", ".")
+	})
+	if err != nil {
+		return NewDataset[int, string](), err
+	}
+	defer cleanup()
+
+	artists := make(map[string]string)
+	err = readCsvFile(fsys, "artists.dat", '\t', func(record []string) error {
+		if len(record) < 2 {
+			return errors.New("Invalid artists.dat")
+		}
+		artists[record[0]] = record[1]
+		return nil
+	})
 	if err != nil {
-		return data, err
+		return NewDataset[int, string](), err
 	}
 
-	itemFile, err := root.Open(itemPath)
+	data := NewDataset[int, string]()
+	data.Grow(92834)
+	err = readCsvFile(fsys, "user_artists.dat", '\t', func(record []string) error {
+		if len(record) < 3 {
+			return errors.New("Invalid user_artists.dat")
+		}
+
+		userId, err := strconv.Atoi(record[0])
+		if err != nil {
+			return err
+		}
+
+		value, err := strconv.ParseFloat(record[2], 32)
+		if err != nil {
+			return err
+		}
+
+		data.Push(userId, artists[record[1]], float32(value))
+		return nil
+	})
+	return data, err
+}
+
+// Loads the Book-Crossing dataset.
+//
+// Items are ISBNs. Values are from 1 to 10 for explicit ratings and 0 for implicit ratings.
+// The dataset must be loaded with FromFS or FromDir.
+func LoadBookCrossing(options ...LoadOption) (*Dataset[int, string], error) {
+	fsys, cleanup, err := loadFS(newLoadConfig(options), nil)
 	if err != nil {
-		return data, err
+		return NewDataset[int, string](), err
 	}
-	defer itemFile.Close()
+	defer cleanup()
+
+	data := NewDataset[int, string]()
+	err = readCsvFile(fsys, "BX-Book-Ratings.csv", ';', func(record []string) error {
+		if len(record) < 3 {
+			return errors.New("Invalid BX-Book-Ratings.csv")
+		}
 
-	movies := make(map[string]string, 1682)
+		userId, err := strconv.Atoi(record[0])
+		if err != nil {
+			return err
+		}
 
-	scanner := bufio.NewScanner(itemFile)
+		value, err := strconv.ParseFloat(record[2], 32)
+		if err != nil {
+			return err
+		}
+
+		data.Push(userId, convertToUtf8(record[1]), float32(value))
+		return nil
+	})
+	return data, err
+}
+
+func loadFS(config *loadConfig, download func(root *os.Root) (fs.FS, func() error, error)) (fs.FS, func() error, error) {
+	if config.fsys != nil {
+		return config.fsys, func() error { return nil }, nil
+	}
+
+	if download == nil {
+		return nil, nil, errors.New("Dataset must be loaded with FromFS or FromDir")
+	}
+
+	root, err := config.openCacheDir()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fsys, closeFiles, err := download(root)
+	if err != nil {
+		root.Close()
+		return nil, nil, err
+	}
+
+	cleanup := func() error {
+		var err error
+		if closeFiles != nil {
+			err = closeFiles()
+		}
+		return errors.Join(err, root.Close())
+	}
+	return fsys, cleanup, nil
+}
+
+func readMovies(fsys fs.FS, name string, sep string) (map[string]string, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	movies := make(map[string]string)
+
+	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
-		row0, rest, _ := strings.Cut(scanner.Text(), "|")
-		row1, _, _ := strings.Cut(rest, "|")
+		row0, rest, _ := strings.Cut(scanner.Text(), sep)
+		row1, _, _ := strings.Cut(rest, sep)
 		movies[row0] = convertToUtf8(row1)
 	}
 
-	dataFile, err := root.Open(dataPath)
+	return movies, scanner.Err()
+}
+
+func readRatings(fsys fs.FS, name string, sep string, movies map[string]string, data *Dataset[int, string]) error {
+	file, err := fsys.Open(name)
 	if err != nil {
-		return data, err
+		return err
 	}
-	defer dataFile.Close()
-
-	data.Grow(100000)
+	defer file.Close()
 
-	scanner = bufio.NewScanner(dataFile)
+	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
-		row0, rest, _ := strings.Cut(scanner.Text(), "\t")
-		row1, rest, _ := strings.Cut(rest, "\t")
-		row2, _, _ := strings.Cut(rest, "\t")
+		row0, rest, _ := strings.Cut(scanner.Text(), sep)
+		row1, rest, _ := strings.Cut(rest, sep)
+		row2, rest, _ := strings.Cut(rest, sep)
+		row3, _, _ := strings.Cut(rest, sep)
 
-		userId, err := strconv.Atoi(row0)
+		err := pushRating(data, row0, movies[row1], row2, row3)
 		if err != nil {
-			return data, err
+			return err
 		}
+	}
+
+	return scanner.Err()
+}
+
+func pushRating(data *Dataset[int, string], user string, item string, value string, timestamp string) error {
+	userId, err := strconv.Atoi(user)
+	if err != nil {
+		return err
+	}
+
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+
+	t, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	data.PushAt(userId, item, float32(v), time.Unix(t, 0))
+	return nil
+}
 
-		value, err := strconv.ParseFloat(row2, 64)
+// skips the header
+func readCsvFile(fsys fs.FS, name string, delimiter rune, fn func(record []string) error) error {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(bufio.NewReader(file))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+
+	_, err = reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
 		if err != nil {
-			return data, err
+			return err
 		}
 
-		data.Push(userId, movies[row1], float32(value))
+		err = fn(record)
+		if err != nil {
+			line, _ := reader.FieldPos(0)
+			return fmt.Errorf("%s line %d: %w", name, line, err)
+		}
 	}
-
-	return data, nil
 }
 
-func cacheDir() (*os.Root, error) {
+func (c *loadConfig) openCacheDir() (*os.Root, error) {
+	if c.cacheDir != "" {
+		err := os.MkdirAll(c.cacheDir, 0755)
+		if err != nil {
+			return nil, err
+		}
+		return os.OpenRoot(c.cacheDir)
+	}
+
 	home, err := os.UserCacheDir()
 	if err != nil {
 		return nil, err
@@ -97,6 +421,7 @@ func cacheDir() (*os.Root, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer homeRoot.Close()
 
 	err = homeRoot.MkdirAll("disco", 0755)
 	if err != nil {
@@ -106,52 +431,153 @@ func cacheDir() (*os.Root, error) {
 	return homeRoot.OpenRoot("disco")
 }
 
-func downloadFile(root *os.Root, filename string, url string, fileHash string) (string, error) {
-	dest := filename
+// reads files from dir in the zip
+func (c *loadConfig) downloadZip(root *os.Root, dest string, url string, dir string) (fs.FS, func() error, error) {
+	err := c.downloadFile(root, dest, url)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := root.Open(dest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	reader, err := zip.NewReader(f, info.Size())
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	fsys, err := fs.Sub(reader, dir)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	return fsys, f.Close, nil
+}
+
+// verifies the file with its pinned checksum
+func (c *loadConfig) downloadFile(root *os.Root, dest string, url string) error {
+	fileHash, ok := checksums[dest]
+	if !ok {
+		return fmt.Errorf("No checksum for %s", dest)
+	}
 
 	_, err := root.Stat(dest)
 	if err == nil {
-		return dest, nil
+		checksum, err := fileChecksum(root, dest)
+		if err != nil {
+			return err
+		}
+		if checksum == fileHash {
+			return nil
+		}
+
+		if c.offline {
+			return fmt.Errorf("Bad checksum for cached file: %s", dest)
+		}
+		c.logger.Warn("Bad checksum for cached file", "path", dest)
+	} else if c.offline {
+		return fmt.Errorf("File not in cache: %s", dest)
 	}
 
 	_, err = root.Stat(filepath.Dir(dest))
 	if err != nil {
 		err = root.MkdirAll(filepath.Dir(dest), 0755)
 		if err != nil {
-			return "", err
+			return err
 		}
 	}
 
-	fmt.Printf("Downloading data from %s\n", url)
-	resp, err := http.Get(url)
+	delay := downloadDelay
+	for attempt := 1; ; attempt++ {
+		retry, err := c.download(root, dest, url, fileHash)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt == downloadAttempts {
+			return err
+		}
+
+		c.logger.Warn("Download failed, retrying", "url", url, "error", err, "delay", delay)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+const downloadAttempts = 3
+const downloadDelay = 500 * time.Millisecond
+
+// writes to a temporary file and renames it on success
+// so interrupted downloads are never cached
+func (c *loadConfig) download(root *os.Root, dest string, url string, fileHash string) (bool, error) {
+	c.logger.Info("Downloading data", "url", url)
+	resp, err := c.client.Get(url)
 	if err != nil {
-		return "", err
+		return true, err
 	}
 	defer resp.Body.Close()
 
-	contents, err := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		return retry, fmt.Errorf("Bad status: %s", resp.Status)
+	}
+
+	tmp := fmt.Sprintf("%s.%016x.tmp", dest, rand.Uint64())
+	f, err := root.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
 	if err != nil {
-		return "", err
+		return false, err
+	}
+	// no-op after rename
+	defer root.Remove(tmp)
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, h), resp.Body)
+	if err != nil {
+		f.Close()
+		return true, err
+	}
+
+	err = f.Sync()
+	if err != nil {
+		f.Close()
+		return false, err
 	}
 
-	b := sha256.Sum256(contents)
-	checksum := hex.EncodeToString(b[:])
+	err = f.Close()
+	if err != nil {
+		return false, err
+	}
+
+	checksum := hex.EncodeToString(h.Sum(nil))
 	if checksum != fileHash {
-		return "", fmt.Errorf("Bad checksum: %s", checksum)
+		return false, fmt.Errorf("Bad checksum: %s", checksum)
 	}
 
-	f, err := root.Create(dest)
+	return false, root.Rename(tmp, dest)
+}
+
+func fileChecksum(root *os.Root, path string) (string, error) {
+	f, err := root.Open(path)
 	if err != nil {
 		return "", err
 	}
 	defer f.Close()
 
-	_, err = f.Write(contents)
+	h := sha256.New()
+	_, err = io.Copy(h, f)
 	if err != nil {
 		return "", err
 	}
-
-	return dest, nil
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
 func convertToUtf8(str string) string {
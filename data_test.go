@@ -0,0 +1,288 @@
+package disco_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/ankane/disco-go"
+)
+
+func TestLoadMovieLensFromDir(t *testing.T) {
+	data, err := disco.LoadMovieLens(disco.FromDir("testdata/ml-100k"))
+	assertNil(t, err)
+	assertEqual(t, 4, data.Len())
+	assertDeepEqual(t, []string{"Toy Story (1995)", "GoldenEye (1995)", "Misérables, Les (1995)"}, data.Items())
+
+	rating := data.UserRatings(2)[0]
+	assertEqual(t, "Toy Story (1995)", rating.ItemId)
+	assertEqual(t, 4.0, rating.Value)
+	assertTrue(t, rating.Time.Equal(time.Unix(888550871, 0)))
+}
+
+func TestLoadMovieLensFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"u.item": &fstest.MapFile{Data: []byte("1|Toy Story (1995)|01-Jan-1995||\n")},
+		"u.data": &fstest.MapFile{Data: []byte("1\t1\t5\t874965758\n")},
+	}
+	data, err := disco.LoadMovieLens(disco.FromFS(fsys))
+	assertNil(t, err)
+	assertEqual(t, 1, data.Len())
+}
+
+func TestLoadMovieLensMissing(t *testing.T) {
+	_, err := disco.LoadMovieLens(disco.FromDir("testdata/missing"))
+	assertTrue(t, os.IsNotExist(err))
+}
+
+func TestLoadMovieLens1M(t *testing.T) {
+	data, err := disco.LoadMovieLens1M(disco.FromDir("testdata/ml-1m"))
+	assertNil(t, err)
+	assertEqual(t, 3, data.Len())
+	assertDeepEqual(t, []string{"Toy Story (1995)", "Jumanji (1995)"}, data.Items())
+}
+
+func TestLoadMovieLens20M(t *testing.T) {
+	data, err := disco.LoadMovieLens20M(disco.FromDir("testdata/ml-20m"))
+	assertNil(t, err)
+	assertEqual(t, 3, data.Len())
+	assertDeepEqual(t, []string{"Toy Story (1995)", "American President, The (1995)"}, data.Items())
+	assertEqual(t, 2.5, data.UserRatings(2)[0].Value)
+}
+
+func TestLoadMovieLens25M(t *testing.T) {
+	// same format as 20M
+	data, err := disco.LoadMovieLens25M(disco.FromDir("testdata/ml-20m"))
+	assertNil(t, err)
+	assertEqual(t, 3, data.Len())
+}
+
+func TestLoadLastFm(t *testing.T) {
+	data, err := disco.LoadLastFm(disco.FromDir("testdata/lastfm"))
+	assertNil(t, err)
+	assertEqual(t, 3, data.Len())
+	assertDeepEqual(t, []string{"Duran Duran", "Morcheeba"}, data.Items())
+	assertEqual(t, 13883.0, data.UserRatings(2)[0].Value)
+}
+
+func TestLoadBookCrossing(t *testing.T) {
+	data, err := disco.LoadBookCrossing(disco.FromDir("testdata/book-crossing"))
+	assertNil(t, err)
+	assertEqual(t, 3, data.Len())
+	assertDeepEqual(t, []int{276725, 276726, 276727}, data.Users())
+	assertDeepEqual(t, []string{"034545104X", "0155061224", "0446520802"}, data.Items())
+	assertEqual(t, 5.0, data.UserRatings(276726)[0].Value)
+
+	_, err = disco.LoadBookCrossing()
+	assertError(t, err, "Dataset must be loaded with FromFS or FromDir")
+}
+
+// serves files for all hosts, with failures for the first requests
+func testClient(t *testing.T, files map[string][]byte, failures ...http.HandlerFunc) (*http.Client, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= len(failures) {
+			failures[requests-1](w, r)
+			return
+		}
+		contents, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(contents)
+	}))
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	assertNil(t, err)
+
+	client := server.Client()
+	transport := client.Transport
+	client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		r = r.Clone(r.Context())
+		r.URL.Scheme = serverURL.Scheme
+		r.URL.Host = serverURL.Host
+		return transport.RoundTrip(r)
+	})
+	return client, &requests
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// serves the fixture files with their checksums
+func movieLensFiles(t *testing.T) map[string][]byte {
+	files := make(map[string][]byte)
+	for _, name := range []string{"u.item", "u.data", "u.user"} {
+		contents, err := os.ReadFile(filepath.Join("testdata/ml-100k", name))
+		assertNil(t, err)
+		files["/datasets/movielens/ml-100k/"+name] = contents
+		disco.SetChecksum(t, "ml-100k/"+name, fmt.Sprintf("%x", sha256.Sum256(contents)))
+	}
+	return files
+}
+
+func TestLoadMovieLensDownload(t *testing.T) {
+	client, requests := testClient(t, movieLensFiles(t))
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	cacheDir := t.TempDir()
+
+	data, err := disco.LoadMovieLens(disco.CacheDir(cacheDir), disco.HTTPClient(client), disco.Logger(logger))
+	assertNil(t, err)
+	assertEqual(t, 4, data.Len())
+	assertEqual(t, 2, *requests)
+	assertTrue(t, strings.Contains(logs.String(), "url=https://files.grouplens.org/datasets/movielens/ml-100k/u.item"))
+
+	// uses cache
+	data, err = disco.LoadMovieLens(disco.CacheDir(cacheDir), disco.Offline())
+	assertNil(t, err)
+	assertEqual(t, 4, data.Len())
+	assertEqual(t, 2, *requests)
+}
+
+func TestLoadMovieLensBadChecksum(t *testing.T) {
+	files := movieLensFiles(t)
+	files["/datasets/movielens/ml-100k/u.item"] = []byte("1|Tampered (1995)|01-Jan-1995||\n")
+	client, _ := testClient(t, files)
+
+	_, err := disco.LoadMovieLens(disco.CacheDir(t.TempDir()), disco.HTTPClient(client), disco.Logger(nil))
+	assertTrue(t, strings.HasPrefix(err.Error(), "Bad checksum: "))
+}
+
+func TestLoadMovieLensOffline(t *testing.T) {
+	_, err := disco.LoadMovieLens(disco.CacheDir(t.TempDir()), disco.Offline())
+	assertError(t, err, "File not in cache: ml-100k/u.item")
+}
+
+func TestLoadMovieLensRetry(t *testing.T) {
+	unavailable := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	interrupted := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000")
+		w.Write([]byte("partial"))
+	}
+	client, requests := testClient(t, movieLensFiles(t), unavailable, interrupted)
+	cacheDir := t.TempDir()
+
+	data, err := disco.LoadMovieLens(disco.CacheDir(cacheDir), disco.HTTPClient(client), disco.Logger(nil))
+	assertNil(t, err)
+	assertEqual(t, 4, data.Len())
+	assertEqual(t, 4, *requests)
+
+	// no temporary files
+	entries, err := os.ReadDir(filepath.Join(cacheDir, "ml-100k"))
+	assertNil(t, err)
+	assertEqual(t, 2, len(entries))
+}
+
+func TestLoadMovieLensNotFound(t *testing.T) {
+	client, requests := testClient(t, map[string][]byte{})
+
+	_, err := disco.LoadMovieLens(disco.CacheDir(t.TempDir()), disco.HTTPClient(client), disco.Logger(nil))
+	assertError(t, err, "Bad status: 404 Not Found")
+	// no retries
+	assertEqual(t, 1, *requests)
+}
+
+func TestLoadMovieLensCorruptCache(t *testing.T) {
+	files := movieLensFiles(t)
+	cacheDir := t.TempDir()
+	err := os.Mkdir(filepath.Join(cacheDir, "ml-100k"), 0755)
+	assertNil(t, err)
+	err = os.WriteFile(filepath.Join(cacheDir, "ml-100k/u.item"), []byte("corrupt"), 0644)
+	assertNil(t, err)
+	err = os.WriteFile(filepath.Join(cacheDir, "ml-100k/u.data"), files["/datasets/movielens/ml-100k/u.data"], 0644)
+	assertNil(t, err)
+
+	_, err = disco.LoadMovieLens(disco.CacheDir(cacheDir), disco.Offline())
+	assertError(t, err, "Bad checksum for cached file: ml-100k/u.item")
+
+	client, requests := testClient(t, files)
+	data, err := disco.LoadMovieLens(disco.CacheDir(cacheDir), disco.HTTPClient(client), disco.Logger(nil))
+	assertNil(t, err)
+	assertEqual(t, 4, data.Len())
+	assertEqual(t, 1, *requests)
+}
+
+// zips the fixture files with their checksum
+func zipFiles(t *testing.T, filename string, dir string, prefix string, names ...string) []byte {
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	for _, name := range names {
+		contents, err := os.ReadFile(filepath.Join(dir, name))
+		assertNil(t, err)
+		f, err := w.Create(prefix + name)
+		assertNil(t, err)
+		_, err = f.Write(contents)
+		assertNil(t, err)
+	}
+	assertNil(t, w.Close())
+	disco.SetChecksum(t, filename, fmt.Sprintf("%x", sha256.Sum256(b.Bytes())))
+	return b.Bytes()
+}
+
+func TestLoadMovieLens1MDownload(t *testing.T) {
+	contents := zipFiles(t, "ml-1m.zip", "testdata/ml-1m", "ml-1m/", "movies.dat", "ratings.dat")
+	client, requests := testClient(t, map[string][]byte{"/datasets/movielens/ml-1m.zip": contents})
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	cacheDir := t.TempDir()
+
+	data, err := disco.LoadMovieLens1M(disco.CacheDir(cacheDir), disco.HTTPClient(client), disco.Logger(logger))
+	assertNil(t, err)
+	assertEqual(t, 3, data.Len())
+	assertEqual(t, 1, *requests)
+	assertTrue(t, strings.Contains(logs.String(), "url=https://files.grouplens.org/datasets/movielens/ml-1m.zip"))
+
+	// uses cache
+	data, err = disco.LoadMovieLens1M(disco.CacheDir(cacheDir), disco.Offline())
+	assertNil(t, err)
+	assertEqual(t, 3, data.Len())
+	assertEqual(t, 1, *requests)
+}
+
+func TestLoadMovieLens1MBadChecksum(t *testing.T) {
+	zipFiles(t, "ml-1m.zip", "testdata/ml-1m", "ml-1m/", "movies.dat", "ratings.dat")
+	client, _ := testClient(t, map[string][]byte{"/datasets/movielens/ml-1m.zip": []byte("tampered")})
+
+	_, err := disco.LoadMovieLens1M(disco.CacheDir(t.TempDir()), disco.HTTPClient(client), disco.Logger(nil))
+	assertTrue(t, strings.HasPrefix(err.Error(), "Bad checksum: "))
+}
+
+func TestLoadMovieLens25MDownload(t *testing.T) {
+	contents := zipFiles(t, "ml-25m.zip", "testdata/ml-20m", "ml-25m/", "movies.csv", "ratings.csv")
+	client, _ := testClient(t, map[string][]byte{"/datasets/movielens/ml-25m.zip": contents})
+
+	data, err := disco.LoadMovieLens25M(disco.CacheDir(t.TempDir()), disco.HTTPClient(client), disco.Logger(nil))
+	assertNil(t, err)
+	assertEqual(t, 3, data.Len())
+}
+
+func TestLoadLastFmDownload(t *testing.T) {
+	contents := zipFiles(t, "hetrec2011-lastfm-2k.zip", "testdata/lastfm", "", "artists.dat", "user_artists.dat")
+	client, _ := testClient(t, map[string][]byte{"/datasets/hetrec2011/hetrec2011-lastfm-2k.zip": contents})
+
+	data, err := disco.LoadLastFm(disco.CacheDir(t.TempDir()), disco.HTTPClient(client), disco.Logger(nil))
+	assertNil(t, err)
+	assertEqual(t, 3, data.Len())
+}
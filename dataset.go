@@ -1,8 +1,12 @@
 package disco
 
 import (
+	"errors"
+	"fmt"
+	"iter"
 	"math/rand/v2"
 	"slices"
+	"time"
 )
 
 // A dataset.
@@ -14,6 +18,7 @@ type rating[T Id, U Id] struct {
 	userId T
 	itemId U
 	value  float32
+	time   time.Time
 }
 
 // Creates a new dataset.
@@ -31,11 +36,82 @@ func (d *Dataset[T, U]) Push(userId T, itemId U, value float32) {
 	d.data = append(d.data, rating[T, U]{userId: userId, itemId: itemId, value: value})
 }
 
+// Adds a rating with a timestamp to the dataset.
+func (d *Dataset[T, U]) PushAt(userId T, itemId U, value float32, t time.Time) {
+	d.data = append(d.data, rating[T, U]{userId: userId, itemId: itemId, value: value, time: t})
+}
+
 // Returns the number of ratings in the dataset.
 func (d *Dataset[T, U]) Len() int {
 	return len(d.data)
 }
 
+// A rating.
+type Rating[T Id, U Id] struct {
+	UserId T
+	ItemId U
+	Value  float32
+	// The zero value if the rating has no timestamp.
+	Time time.Time
+}
+
+// Returns an iterator over the ratings in the order they were added.
+func (d *Dataset[T, U]) All() iter.Seq[Rating[T, U]] {
+	return func(yield func(Rating[T, U]) bool) {
+		for _, rating := range d.data {
+			if !yield(rating.export()) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the unique user ids in the order they first appear.
+func (d *Dataset[T, U]) Users() []T {
+	seen := make(map[T]bool)
+	ids := make([]T, 0)
+	for _, rating := range d.data {
+		if !seen[rating.userId] {
+			seen[rating.userId] = true
+			ids = append(ids, rating.userId)
+		}
+	}
+	return ids
+}
+
+// Returns the unique item ids in the order they first appear.
+func (d *Dataset[T, U]) Items() []U {
+	seen := make(map[U]bool)
+	ids := make([]U, 0)
+	for _, rating := range d.data {
+		if !seen[rating.itemId] {
+			seen[rating.itemId] = true
+			ids = append(ids, rating.itemId)
+		}
+	}
+	return ids
+}
+
+// Returns the ratings for a user in the order they were added.
+func (d *Dataset[T, U]) UserRatings(userId T) []Rating[T, U] {
+	ratings := make([]Rating[T, U], 0)
+	for _, rating := range d.data {
+		if rating.userId == userId {
+			ratings = append(ratings, rating.export())
+		}
+	}
+	return ratings
+}
+
+// Adds the ratings from another dataset.
+func (d *Dataset[T, U]) Append(other *Dataset[T, U]) {
+	d.data = append(d.data, other.data...)
+}
+
+func (r rating[T, U]) export() Rating[T, U] {
+	return Rating[T, U]{UserId: r.userId, ItemId: r.itemId, Value: r.value, Time: r.time}
+}
+
 // Splits the dataset into training and validation sets.
 func (d *Dataset[T, U]) SplitRandom(p float32) (*Dataset[T, U], *Dataset[T, U]) {
 	index := int(p * float32(len(d.data)))
@@ -44,7 +120,286 @@ func (d *Dataset[T, U]) SplitRandom(p float32) (*Dataset[T, U], *Dataset[T, U])
 	rand.Shuffle(len(data), func(i, j int) {
 		data[i], data[j] = data[j], data[i]
 	})
-	trainSet := &Dataset[T, U]{data: data[:index]}
+	// limit capacity so appending to the training set does not overwrite the validation set
+	trainSet := &Dataset[T, U]{data: data[:index:index]}
 	validSet := &Dataset[T, U]{data: data[index:]}
 	return trainSet, validSet
 }
+
+// Splits the ratings for each user into training and validation sets.
+//
+// Users always have at least one rating in the training set.
+func (d *Dataset[T, U]) SplitPerUser(p float32) (*Dataset[T, U], *Dataset[T, U]) {
+	return d.splitPerUser(shuffleIndexes, func(n int) int {
+		return n - int(p*float32(n))
+	})
+}
+
+// Splits the dataset into training and validation sets,
+// with count ratings for each user in the validation set.
+//
+// Users always have at least one rating in the training set.
+func (d *Dataset[T, U]) SplitPerUserCount(count int) (*Dataset[T, U], *Dataset[T, U]) {
+	return d.splitPerUser(shuffleIndexes, func(n int) int {
+		return count
+	})
+}
+
+// Splits the dataset into training and validation sets,
+// with the last count ratings for each user in the validation set.
+//
+// Ratings are ordered by timestamp, then by the order they were added.
+// Users always have at least one rating in the training set.
+func (d *Dataset[T, U]) SplitLastOut(count int) (*Dataset[T, U], *Dataset[T, U]) {
+	// most recent first
+	order := func(indexes []int) {
+		slices.SortFunc(indexes, func(i, j int) int {
+			c := d.data[j].time.Compare(d.data[i].time)
+			if c == 0 {
+				c = j - i
+			}
+			return c
+		})
+	}
+	return d.splitPerUser(order, func(n int) int {
+		return count
+	})
+}
+
+// Splits the dataset into training and validation sets,
+// with ratings before the cutoff in the training set.
+//
+// Ratings without a timestamp are in the training set.
+func (d *Dataset[T, U]) SplitByTime(cutoff time.Time) (*Dataset[T, U], *Dataset[T, U]) {
+	valid := make([]bool, len(d.data))
+	for i, rating := range d.data {
+		valid[i] = !rating.time.Before(cutoff)
+	}
+	return d.partition(valid)
+}
+
+// Splits the dataset into training and validation sets,
+// with the earliest ratings in the training set.
+//
+// Ratings with the same timestamp are ordered by the order they were added.
+func (d *Dataset[T, U]) SplitByTimeFraction(p float32) (*Dataset[T, U], *Dataset[T, U]) {
+	indexes := make([]int, len(d.data))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	slices.SortStableFunc(indexes, func(i, j int) int {
+		return d.data[i].time.Compare(d.data[j].time)
+	})
+
+	valid := make([]bool, len(d.data))
+	for _, i := range indexes[int(p*float32(len(d.data))):] {
+		valid[i] = true
+	}
+	return d.partition(valid)
+}
+
+func (d *Dataset[T, U]) splitPerUser(order func(indexes []int), validCount func(n int) int) (*Dataset[T, U], *Dataset[T, U]) {
+	userIndexes := make(map[T][]int)
+	for i, rating := range d.data {
+		userIndexes[rating.userId] = append(userIndexes[rating.userId], i)
+	}
+
+	valid := make([]bool, len(d.data))
+	for _, indexes := range userIndexes {
+		order(indexes)
+		n := min(validCount(len(indexes)), len(indexes)-1)
+		for _, i := range indexes[:max(n, 0)] {
+			valid[i] = true
+		}
+	}
+
+	return d.partition(valid)
+}
+
+func shuffleIndexes(indexes []int) {
+	rand.Shuffle(len(indexes), func(i, j int) {
+		indexes[i], indexes[j] = indexes[j], indexes[i]
+	})
+}
+
+func (d *Dataset[T, U]) partition(valid []bool) (*Dataset[T, U], *Dataset[T, U]) {
+	trainSet := &Dataset[T, U]{data: make([]rating[T, U], 0, len(d.data))}
+	validSet := &Dataset[T, U]{data: []rating[T, U]{}}
+	for i, rating := range d.data {
+		if valid[i] {
+			validSet.data = append(validSet.data, rating)
+		} else {
+			trainSet.data = append(trainSet.data, rating)
+		}
+	}
+	return trainSet, validSet
+}
+
+// A policy for handling duplicate ratings for the same user and item.
+type DuplicatePolicy int
+
+const (
+	// Sums the values.
+	DuplicateSum DuplicatePolicy = iota
+	// Uses the last value.
+	DuplicateLast
+	// Uses the maximum value.
+	DuplicateMax
+	// Uses the mean value.
+	DuplicateMean
+	// Returns an error.
+	DuplicateError
+)
+
+type pair[T Id, U Id] struct {
+	userId T
+	itemId U
+}
+
+// Returns the number of duplicate ratings for the same user and item.
+func (d *Dataset[T, U]) Duplicates() int {
+	seen := make(map[pair[T, U]]bool, len(d.data))
+	duplicates := 0
+	for _, rating := range d.data {
+		key := pair[T, U]{userId: rating.userId, itemId: rating.itemId}
+		if seen[key] {
+			duplicates++
+		} else {
+			seen[key] = true
+		}
+	}
+	return duplicates
+}
+
+// Combines duplicate ratings for the same user and item.
+//
+// Each combined rating keeps the position of the first rating and the latest timestamp.
+func (d *Dataset[T, U]) Deduplicate(policy DuplicatePolicy) (*Dataset[T, U], error) {
+	if policy < DuplicateSum || policy > DuplicateError {
+		return nil, errors.New("Invalid duplicate policy")
+	}
+
+	index := make(map[pair[T, U]]int, len(d.data))
+	data := make([]rating[T, U], 0, len(d.data))
+	counts := make([]int, 0, len(d.data))
+
+	for _, rating := range d.data {
+		key := pair[T, U]{userId: rating.userId, itemId: rating.itemId}
+		j, ok := index[key]
+		if !ok {
+			index[key] = len(data)
+			data = append(data, rating)
+			counts = append(counts, 1)
+			continue
+		}
+
+		existing := &data[j]
+		switch policy {
+		case DuplicateSum, DuplicateMean:
+			existing.value += rating.value
+		case DuplicateLast:
+			existing.value = rating.value
+		case DuplicateMax:
+			existing.value = max(existing.value, rating.value)
+		case DuplicateError:
+			return nil, fmt.Errorf("Duplicate rating for user %v and item %v", rating.userId, rating.itemId)
+		}
+		if policy == DuplicateLast || rating.time.After(existing.time) {
+			existing.time = rating.time
+		}
+		counts[j]++
+	}
+
+	if policy == DuplicateMean {
+		for j := range data {
+			data[j].value /= float32(counts[j])
+		}
+	}
+
+	return &Dataset[T, U]{data: data}, nil
+}
+
+// Returns a new dataset with users that have at least n ratings.
+func (d *Dataset[T, U]) MinUserRatings(n int) *Dataset[T, U] {
+	counts := make(map[T]int)
+	for _, rating := range d.data {
+		counts[rating.userId]++
+	}
+	return d.filter(func(rating rating[T, U]) bool {
+		return counts[rating.userId] >= n
+	})
+}
+
+// Returns a new dataset with items that have at least n ratings.
+func (d *Dataset[T, U]) MinItemRatings(n int) *Dataset[T, U] {
+	counts := make(map[U]int)
+	for _, rating := range d.data {
+		counts[rating.itemId]++
+	}
+	return d.filter(func(rating rating[T, U]) bool {
+		return counts[rating.itemId] >= n
+	})
+}
+
+// Returns a new dataset where every user has at least userCount ratings
+// and every item has at least itemCount ratings.
+func (d *Dataset[T, U]) KCore(userCount int, itemCount int) *Dataset[T, U] {
+	data := d
+	for {
+		n := data.Len()
+		data = data.MinUserRatings(userCount).MinItemRatings(itemCount)
+		if data.Len() == n {
+			return data
+		}
+	}
+}
+
+// Returns a new dataset with only the specified users.
+func (d *Dataset[T, U]) KeepUsers(userIds ...T) *Dataset[T, U] {
+	ids := idSet(userIds)
+	return d.filter(func(rating rating[T, U]) bool {
+		return ids[rating.userId]
+	})
+}
+
+// Returns a new dataset without the specified users.
+func (d *Dataset[T, U]) DropUsers(userIds ...T) *Dataset[T, U] {
+	ids := idSet(userIds)
+	return d.filter(func(rating rating[T, U]) bool {
+		return !ids[rating.userId]
+	})
+}
+
+// Returns a new dataset with only the specified items.
+func (d *Dataset[T, U]) KeepItems(itemIds ...U) *Dataset[T, U] {
+	ids := idSet(itemIds)
+	return d.filter(func(rating rating[T, U]) bool {
+		return ids[rating.itemId]
+	})
+}
+
+// Returns a new dataset without the specified items.
+func (d *Dataset[T, U]) DropItems(itemIds ...U) *Dataset[T, U] {
+	ids := idSet(itemIds)
+	return d.filter(func(rating rating[T, U]) bool {
+		return !ids[rating.itemId]
+	})
+}
+
+func (d *Dataset[T, U]) filter(keep func(rating rating[T, U]) bool) *Dataset[T, U] {
+	data := make([]rating[T, U], 0, len(d.data))
+	for _, rating := range d.data {
+		if keep(rating) {
+			data = append(data, rating)
+		}
+	}
+	return &Dataset[T, U]{data: data}
+}
+
+func idSet[T Id](ids []T) map[T]bool {
+	set := make(map[T]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
@@ -0,0 +1,259 @@
+package disco_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ankane/disco-go"
+)
+
+func duplicateData() *disco.Dataset[int, string] {
+	data := disco.NewDataset[int, string]()
+	data.Push(1, "A", 1.0)
+	data.Push(1, "B", 2.0)
+	data.Push(1, "A", 5.0)
+	data.Push(2, "A", 3.0)
+	data.Push(1, "A", 3.0)
+	return data
+}
+
+func TestDuplicates(t *testing.T) {
+	assertEqual(t, 2, duplicateData().Duplicates())
+	assertEqual(t, 0, disco.NewDataset[int, string]().Duplicates())
+}
+
+func TestDeduplicate(t *testing.T) {
+	policies := []disco.DuplicatePolicy{disco.DuplicateSum, disco.DuplicateLast, disco.DuplicateMax, disco.DuplicateMean}
+	expected := []float32{12.0, 6.0, 8.0, 6.0}
+	for i, policy := range policies {
+		data, err := duplicateData().Deduplicate(policy)
+		assertNil(t, err)
+		assertEqual(t, 3, data.Len())
+		assertEqual(t, 0, data.Duplicates())
+
+		// sum of values for item A
+		baseline, err := disco.FitPopularSum(data)
+		assertNil(t, err)
+		assertEqual(t, expected[i], baseline.Predict(1, "A"))
+	}
+}
+
+func TestDeduplicateError(t *testing.T) {
+	_, err := duplicateData().Deduplicate(disco.DuplicateError)
+	assertError(t, err, "Duplicate rating for user 1 and item A")
+
+	_, err = disco.FitExplicit(duplicateData(), disco.Duplicates(disco.DuplicateError))
+	assertError(t, err, "Duplicate rating for user 1 and item A")
+}
+
+func filterData() *disco.Dataset[int, string] {
+	data := disco.NewDataset[int, string]()
+	data.Push(1, "A", 1.0)
+	data.Push(1, "B", 1.0)
+	data.Push(1, "C", 1.0)
+	data.Push(2, "A", 1.0)
+	data.Push(2, "B", 1.0)
+	data.Push(3, "A", 1.0)
+	data.Push(3, "C", 1.0)
+	data.Push(4, "D", 1.0)
+	return data
+}
+
+func TestMinUserRatings(t *testing.T) {
+	data := filterData()
+	filtered := data.MinUserRatings(2)
+	assertEqual(t, 7, filtered.Len())
+	assertEqual(t, 8, data.Len())
+}
+
+func TestMinItemRatings(t *testing.T) {
+	assertEqual(t, 3, filterData().MinItemRatings(3).Len())
+}
+
+func TestKCore(t *testing.T) {
+	data := filterData()
+	assertEqual(t, 7, data.KCore(2, 2).Len())
+
+	// removing items with fewer than three ratings
+	// leaves every user with one rating
+	data.Push(4, "A", 1.0)
+	data.Push(4, "E", 1.0)
+	assertEqual(t, 0, data.KCore(2, 3).Len())
+	assertEqual(t, 8, data.KCore(1, 2).Len())
+}
+
+func TestKeepDrop(t *testing.T) {
+	data := filterData()
+	assertEqual(t, 5, data.KeepUsers(1, 2).Len())
+	assertEqual(t, 3, data.DropUsers(1, 2).Len())
+	assertEqual(t, 4, data.KeepItems("A", "D").Len())
+	assertEqual(t, 4, data.DropItems("A", "D").Len())
+	assertEqual(t, 0, data.KeepUsers().Len())
+}
+
+func TestStats(t *testing.T) {
+	data := filterData()
+	data.Push(1, "A", 5.0)
+
+	stats := data.Stats()
+	assertEqual(t, 9, stats.Ratings)
+	assertEqual(t, 4, stats.Users)
+	assertEqual(t, 4, stats.Items)
+	assertInDelta(t, 0.5, float32(stats.Sparsity), 0.0001)
+	assertEqual(t, 1, stats.Duplicates)
+	assertEqual(t, 1.0, stats.MinValue)
+	assertEqual(t, 5.0, stats.MaxValue)
+	assertInDelta(t, 1.4444, stats.MeanValue, 0.0001)
+	assertInDelta(t, 1.2571, stats.StdDevValue, 0.0001)
+	assertEqual(t, disco.Counts{Min: 1, P25: 2, Median: 2, P75: 2, Max: 4, Mean: 2.25}, stats.UserRatings)
+	assertEqual(t, disco.Counts{Min: 1, P25: 2, Median: 2, P75: 2, Max: 4, Mean: 2.25}, stats.ItemRatings)
+
+	expected := `Ratings: 9
+Users: 4
+Items: 4
+Sparsity: 50.00%
+Duplicates: 1
+Values: min 1, max 5, mean 1.44, std dev 1.26
+Ratings per user: min 1, 25% 2, median 2, 75% 2, max 4, mean 2.25
+Ratings per item: min 1, 25% 2, median 2, 75% 2, max 4, mean 2.25`
+	assertEqual(t, expected, stats.String())
+}
+
+func TestStatsEmpty(t *testing.T) {
+	stats := disco.NewDataset[int, string]().Stats()
+	assertEqual(t, 0, stats.Ratings)
+	assertEqual(t, 0.0, stats.Sparsity)
+}
+
+func splitData() *disco.Dataset[int, int] {
+	data := disco.NewDataset[int, int]()
+	for u := range 10 {
+		for i := range u + 1 {
+			data.Push(u, i, 1.0)
+		}
+	}
+	return data
+}
+
+func TestSplitPerUser(t *testing.T) {
+	trainSet, validSet := splitData().SplitPerUser(0.8)
+	assertEqual(t, 41, trainSet.Len())
+	assertEqual(t, 14, validSet.Len())
+
+	baseline, err := disco.FitPopular(trainSet)
+	assertNil(t, err)
+	assertEqual(t, 10, len(baseline.UserIds()))
+}
+
+func TestSplitPerUserCount(t *testing.T) {
+	trainSet, validSet := splitData().SplitPerUserCount(2)
+	assertEqual(t, 38, trainSet.Len())
+	assertEqual(t, 17, validSet.Len())
+
+	baseline, err := disco.FitPopular(trainSet)
+	assertNil(t, err)
+	assertEqual(t, 10, len(baseline.UserIds()))
+}
+
+func timeData() *disco.Dataset[int, string] {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := disco.NewDataset[int, string]()
+	data.PushAt(1, "A", 1.0, start.Add(3*time.Hour))
+	data.PushAt(1, "B", 1.0, start)
+	data.PushAt(1, "C", 1.0, start.Add(2*time.Hour))
+	data.PushAt(2, "A", 1.0, start.Add(time.Hour))
+	data.PushAt(2, "B", 1.0, start.Add(time.Hour))
+	data.PushAt(3, "C", 1.0, start.Add(4*time.Hour))
+	return data
+}
+
+func TestSplitByTime(t *testing.T) {
+	cutoff := time.Date(2025, 1, 1, 2, 0, 0, 0, time.UTC)
+	trainSet, validSet := timeData().SplitByTime(cutoff)
+
+	baseline, err := disco.FitPopular(trainSet)
+	assertNil(t, err)
+	assertDeepEqual(t, []int{1, 2}, baseline.UserIds())
+	assertDeepEqual(t, []string{"B", "A"}, baseline.ItemIds())
+
+	baseline, err = disco.FitPopular(validSet)
+	assertNil(t, err)
+	assertDeepEqual(t, []int{1, 3}, baseline.UserIds())
+	assertDeepEqual(t, []string{"A", "C"}, baseline.ItemIds())
+}
+
+func TestSplitByTimeFraction(t *testing.T) {
+	trainSet, validSet := timeData().SplitByTimeFraction(0.5)
+	assertEqual(t, 3, trainSet.Len())
+	assertEqual(t, 3, validSet.Len())
+
+	baseline, err := disco.FitPopular(validSet)
+	assertNil(t, err)
+	assertDeepEqual(t, []int{1, 3}, baseline.UserIds())
+}
+
+func TestSplitLastOut(t *testing.T) {
+	trainSet, validSet := timeData().SplitLastOut(1)
+	assertEqual(t, 4, trainSet.Len())
+	assertEqual(t, 2, validSet.Len())
+
+	// ties use the order ratings were added
+	baseline, err := disco.FitPopular(validSet)
+	assertNil(t, err)
+	assertDeepEqual(t, []int{1, 2}, baseline.UserIds())
+	assertDeepEqual(t, []string{"A", "B"}, baseline.ItemIds())
+}
+
+func TestAll(t *testing.T) {
+	data := timeData()
+	values := []string{}
+	for rating := range data.All() {
+		values = append(values, fmt.Sprintf("%d %s %d", rating.UserId, rating.ItemId, rating.Time.Hour()))
+		if len(values) == 3 {
+			break
+		}
+	}
+	assertDeepEqual(t, []string{"1 A 3", "1 B 0", "1 C 2"}, values)
+}
+
+func TestUsersItems(t *testing.T) {
+	data := filterData()
+	assertDeepEqual(t, []int{1, 2, 3, 4}, data.Users())
+	assertDeepEqual(t, []string{"A", "B", "C", "D"}, data.Items())
+}
+
+func TestUserRatings(t *testing.T) {
+	ratings := duplicateData().UserRatings(1)
+	assertEqual(t, 4, len(ratings))
+	assertEqual(t, "B", ratings[1].ItemId)
+	assertEqual(t, 2.0, ratings[1].Value)
+
+	assertEqual(t, 0, len(duplicateData().UserRatings(3)))
+}
+
+func TestAppend(t *testing.T) {
+	data := duplicateData()
+	data.Append(filterData())
+	assertEqual(t, 13, data.Len())
+	assertDeepEqual(t, []int{1, 2, 3, 4}, data.Users())
+}
+
+func TestAppendSplit(t *testing.T) {
+	data := disco.NewDataset[int, string]()
+	for i := range 10 {
+		data.Push(i, "A", 1.0)
+	}
+	trainSet, validSet := data.SplitRandom(0.5)
+
+	extra := disco.NewDataset[int, string]()
+	extra.Push(100, "B", 1.0)
+	trainSet.Append(extra)
+	trainSet.Push(101, "C", 1.0)
+
+	assertEqual(t, 7, trainSet.Len())
+	assertEqual(t, 5, validSet.Len())
+	assertEqual(t, 0, len(validSet.UserRatings(100)))
+	assertEqual(t, 0, len(validSet.UserRatings(101)))
+	assertNotContains(t, validSet.Items(), "B")
+}
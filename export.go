@@ -0,0 +1,248 @@
+package disco
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A format for factors.
+type FactorFormat int
+
+const (
+	// NumPy .npy with a float32 array.
+	FormatNpy FactorFormat = iota
+	// word2vec text with a header and an id followed by factors on each line.
+	// Whitespace in ids is replaced with underscores.
+	FormatWord2Vec
+	// Tab-separated factors for TensorBoard Projector.
+	FormatProjector
+	// Ids for TensorBoard Projector, in the same order as FormatProjector.
+	// Tabs and newlines in ids are replaced with spaces.
+	FormatProjectorMetadata
+)
+
+// Writes user factors in the specified format.
+func (r *Recommender[T, U]) WriteUserFactors(w io.Writer, format FactorFormat) error {
+	return writeFactors(w, format, r.userIds, r.userFactors)
+}
+
+// Writes item factors in the specified format.
+func (r *Recommender[T, U]) WriteItemFactors(w io.Writer, format FactorFormat) error {
+	return writeFactors(w, format, r.itemIds, r.itemFactors)
+}
+
+// Writes ids and factors in NumPy .npz format.
+//
+// The archive has user_ids, item_ids, user_factors, and item_factors arrays.
+func (r *Recommender[T, U]) WriteNpz(w io.Writer) error {
+	z := zip.NewWriter(w)
+
+	arrays := []struct {
+		name  string
+		write func(w io.Writer) error
+	}{
+		{"user_ids", func(w io.Writer) error { return writeNpyIds(w, r.userIds) }},
+		{"item_ids", func(w io.Writer) error { return writeNpyIds(w, r.itemIds) }},
+		{"user_factors", func(w io.Writer) error { return writeNpyFactors(w, r.userFactors) }},
+		{"item_factors", func(w io.Writer) error { return writeNpyFactors(w, r.itemFactors) }},
+	}
+	for _, array := range arrays {
+		f, err := z.Create(array.name + ".npy")
+		if err != nil {
+			return err
+		}
+		err = array.write(f)
+		if err != nil {
+			return err
+		}
+	}
+
+	return z.Close()
+}
+
+func writeFactors[T Id](w io.Writer, format FactorFormat, ids []T, factors *matrix) error {
+	switch format {
+	case FormatNpy:
+		return writeNpyFactors(w, factors)
+	case FormatWord2Vec:
+		bw := bufio.NewWriter(w)
+		fmt.Fprintf(bw, "%d %d\n", factors.rows, factors.cols)
+		for i, id := range ids {
+			bw.WriteString(strings.Map(word2VecRune, fmt.Sprint(id)))
+			for _, v := range factors.Row(i) {
+				bw.WriteByte(' ')
+				bw.WriteString(formatFloat(v))
+			}
+			bw.WriteByte('\n')
+		}
+		return bw.Flush()
+	case FormatProjector:
+		bw := bufio.NewWriter(w)
+		for i := range factors.rows {
+			for j, v := range factors.Row(i) {
+				if j > 0 {
+					bw.WriteByte('\t')
+				}
+				bw.WriteString(formatFloat(v))
+			}
+			bw.WriteByte('\n')
+		}
+		return bw.Flush()
+	case FormatProjectorMetadata:
+		bw := bufio.NewWriter(w)
+		for _, id := range ids {
+			bw.WriteString(strings.Map(projectorRune, fmt.Sprint(id)))
+			bw.WriteByte('\n')
+		}
+		return bw.Flush()
+	default:
+		return errors.New("Invalid factor format")
+	}
+}
+
+// fields are separated by whitespace
+func word2VecRune(r rune) rune {
+	if unicode.IsSpace(r) {
+		return '_'
+	}
+	return r
+}
+
+// fields are separated by tabs and rows by newlines
+func projectorRune(r rune) rune {
+	if r == '\t' || r == '\n' || r == '\r' {
+		return ' '
+	}
+	return r
+}
+
+func formatFloat(v float32) string {
+	return strconv.FormatFloat(float64(v), 'g', -1, 32)
+}
+
+// https://numpy.org/doc/stable/reference/generated/numpy.lib.format.html
+func writeNpyHeader(w io.Writer, descr string, shape string) error {
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': %s, }", descr, shape)
+	// pad so data is aligned to 64 bytes
+	total := 10 + len(header) + 1
+	header += strings.Repeat(" ", (64-total%64)%64) + "\n"
+
+	_, err := io.WriteString(w, "\x93NUMPY\x01\x00")
+	if err != nil {
+		return err
+	}
+	err = binary.Write(w, binary.LittleEndian, uint16(len(header)))
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, header)
+	return err
+}
+
+func writeNpyFactors(w io.Writer, factors *matrix) error {
+	err := writeNpyHeader(w, "<f4", fmt.Sprintf("(%d, %d)", factors.rows, factors.cols))
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	buf := make([]byte, 4)
+	for _, v := range factors.data {
+		binary.LittleEndian.PutUint32(buf, math.Float32bits(v))
+		bw.Write(buf)
+	}
+	return bw.Flush()
+}
+
+// strings use fixed-width UTF-32 so they load without pickle
+func writeNpyIds[T Id](w io.Writer, ids []T) error {
+	shape := fmt.Sprintf("(%d,)", len(ids))
+	bw := bufio.NewWriter(w)
+
+	switch v := any(ids).(type) {
+	case []string:
+		width := 1
+		for _, id := range v {
+			width = max(width, utf8.RuneCountInString(id))
+		}
+		err := writeNpyHeader(bw, fmt.Sprintf("<U%d", width), shape)
+		if err != nil {
+			return err
+		}
+		buf := make([]byte, 4)
+		for _, id := range v {
+			n := 0
+			for _, c := range id {
+				binary.LittleEndian.PutUint32(buf, uint32(c))
+				bw.Write(buf)
+				n++
+			}
+			for ; n < width; n++ {
+				bw.Write([]byte{0, 0, 0, 0})
+			}
+		}
+	default:
+		descr := "<i8"
+		var zero T
+		if isUnsigned(zero) {
+			descr = "<u8"
+		}
+		err := writeNpyHeader(bw, descr, shape)
+		if err != nil {
+			return err
+		}
+		buf := make([]byte, 8)
+		for _, id := range ids {
+			binary.LittleEndian.PutUint64(buf, idBits(id))
+			bw.Write(buf)
+		}
+	}
+
+	return bw.Flush()
+}
+
+func isUnsigned(id any) bool {
+	switch id.(type) {
+	case uint, uint8, uint16, uint32, uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// two's complement for signed ids
+func idBits(id any) uint64 {
+	switch v := id.(type) {
+	case int:
+		return uint64(v)
+	case int8:
+		return uint64(v)
+	case int16:
+		return uint64(v)
+	case int32:
+		return uint64(v)
+	case int64:
+		return uint64(v)
+	case uint:
+		return uint64(v)
+	case uint8:
+		return uint64(v)
+	case uint16:
+		return uint64(v)
+	case uint32:
+		return uint64(v)
+	case uint64:
+		return v
+	default:
+		return 0
+	}
+}
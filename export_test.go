@@ -0,0 +1,166 @@
+package disco_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ankane/disco-go"
+)
+
+// returns the header and data
+func readNpy(t *testing.T, b []byte) (string, []byte) {
+	assertEqual(t, "\x93NUMPY\x01\x00", string(b[:8]))
+	n := int(binary.LittleEndian.Uint16(b[8:10]))
+	assertEqual(t, 0, (10+n)%64)
+	assertEqual(t, byte('\n'), b[10+n-1])
+	return strings.TrimSpace(string(b[10 : 10+n])), b[10+n:]
+}
+
+func exportModel(t *testing.T) *disco.Recommender[int, string] {
+	recommender, err := disco.FitExplicit(baselineData(), disco.Factors(3))
+	assertNil(t, err)
+	return recommender
+}
+
+func TestWriteFactorsNpy(t *testing.T) {
+	recommender := exportModel(t)
+
+	var b bytes.Buffer
+	err := recommender.WriteItemFactors(&b, disco.FormatNpy)
+	assertNil(t, err)
+
+	header, data := readNpy(t, b.Bytes())
+	assertEqual(t, "{'descr': '<f4', 'fortran_order': False, 'shape': (4, 3), }", header)
+	assertEqual(t, 4*3*4, len(data))
+
+	// row-major
+	factors := recommender.ItemFactors("B")
+	assertEqual(t, factors[2], math.Float32frombits(binary.LittleEndian.Uint32(data[5*4:])))
+}
+
+func TestWriteNpz(t *testing.T) {
+	recommender := exportModel(t)
+
+	var b bytes.Buffer
+	err := recommender.WriteNpz(&b)
+	assertNil(t, err)
+
+	r, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	assertNil(t, err)
+
+	arrays := make(map[string][]byte)
+	names := []string{}
+	for _, f := range r.File {
+		rc, err := f.Open()
+		assertNil(t, err)
+		contents, err := io.ReadAll(rc)
+		assertNil(t, err)
+		arrays[f.Name] = contents
+		names = append(names, f.Name)
+	}
+	assertDeepEqual(t, []string{"user_ids.npy", "item_ids.npy", "user_factors.npy", "item_factors.npy"}, names)
+
+	header, data := readNpy(t, arrays["user_ids.npy"])
+	assertEqual(t, "{'descr': '<i8', 'fortran_order': False, 'shape': (3,), }", header)
+	assertEqual(t, uint64(2), binary.LittleEndian.Uint64(data[8:]))
+
+	header, data = readNpy(t, arrays["item_ids.npy"])
+	assertEqual(t, "{'descr': '<U1', 'fortran_order': False, 'shape': (4,), }", header)
+	assertEqual(t, "A\x00\x00\x00B\x00\x00\x00C\x00\x00\x00D\x00\x00\x00", string(data))
+
+	header, _ = readNpy(t, arrays["user_factors.npy"])
+	assertEqual(t, "{'descr': '<f4', 'fortran_order': False, 'shape': (3, 3), }", header)
+}
+
+func TestWriteNpzStringIds(t *testing.T) {
+	data := disco.NewDataset[string, uint8]()
+	data.Push("ab", 1, 1.0)
+	data.Push("é", 2, 1.0)
+	recommender, err := disco.FitImplicit(data)
+	assertNil(t, err)
+
+	var b bytes.Buffer
+	err = recommender.WriteNpz(&b)
+	assertNil(t, err)
+
+	r, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	assertNil(t, err)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		assertNil(t, err)
+		contents, err := io.ReadAll(rc)
+		assertNil(t, err)
+
+		header, data := readNpy(t, contents)
+		switch f.Name {
+		case "user_ids.npy":
+			assertEqual(t, "{'descr': '<U2', 'fortran_order': False, 'shape': (2,), }", header)
+			assertEqual(t, "a\x00\x00\x00b\x00\x00\x00\xe9\x00\x00\x00\x00\x00\x00\x00", string(data))
+		case "item_ids.npy":
+			assertEqual(t, "{'descr': '<u8', 'fortran_order': False, 'shape': (2,), }", header)
+		}
+	}
+}
+
+func TestWriteFactorsWord2Vec(t *testing.T) {
+	recommender := exportModel(t)
+
+	var b strings.Builder
+	err := recommender.WriteUserFactors(&b, disco.FormatWord2Vec)
+	assertNil(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	assertEqual(t, 4, len(lines))
+	assertEqual(t, "3 3", lines[0])
+	assertEqual(t, 4, len(strings.Split(lines[1], " ")))
+	assertTrue(t, strings.HasPrefix(lines[1], "1 "))
+}
+
+func TestWriteFactorsProjector(t *testing.T) {
+	recommender := exportModel(t)
+
+	var vectors strings.Builder
+	err := recommender.WriteItemFactors(&vectors, disco.FormatProjector)
+	assertNil(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(vectors.String(), "\n"), "\n")
+	assertEqual(t, 4, len(lines))
+	assertEqual(t, 3, len(strings.Split(lines[0], "\t")))
+
+	var metadata strings.Builder
+	err = recommender.WriteItemFactors(&metadata, disco.FormatProjectorMetadata)
+	assertNil(t, err)
+	assertEqual(t, "A\nB\nC\nD\n", metadata.String())
+}
+
+func TestWriteFactorsSpecialIds(t *testing.T) {
+	data := disco.NewDataset[int, string]()
+	data.Push(1, "Star Wars (1977)", 1.0)
+	data.Push(1, "Tab\tNew\nLine", 1.0)
+	recommender, err := disco.FitImplicit(data, disco.Factors(2))
+	assertNil(t, err)
+
+	var b strings.Builder
+	err = recommender.WriteItemFactors(&b, disco.FormatWord2Vec)
+	assertNil(t, err)
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	assertEqual(t, 3, len(lines))
+	assertEqual(t, "Star_Wars_(1977)", strings.Fields(lines[1])[0])
+	assertEqual(t, 3, len(strings.Fields(lines[1])))
+	assertEqual(t, "Tab_New_Line", strings.Fields(lines[2])[0])
+
+	var metadata strings.Builder
+	err = recommender.WriteItemFactors(&metadata, disco.FormatProjectorMetadata)
+	assertNil(t, err)
+	assertEqual(t, "Star Wars (1977)\nTab New Line\n", metadata.String())
+}
+
+func TestWriteFactorsInvalidFormat(t *testing.T) {
+	err := exportModel(t).WriteItemFactors(io.Discard, disco.FactorFormat(10))
+	assertError(t, err, "Invalid factor format")
+}
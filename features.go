@@ -0,0 +1,27 @@
+package disco
+
+// Features for users or items.
+type Features[T Id] struct {
+	data []feature[T]
+}
+
+type feature[T Id] struct {
+	id    T
+	name  string
+	value float32
+}
+
+// Creates a new set of features.
+func NewFeatures[T Id]() *Features[T] {
+	return &Features[T]{data: []feature[T]{}}
+}
+
+// Adds a feature.
+func (f *Features[T]) Push(id T, name string, value float32) {
+	f.data = append(f.data, feature[T]{id: id, name: name, value: value})
+}
+
+// Returns the number of features.
+func (f *Features[T]) Len() int {
+	return len(f.data)
+}
@@ -0,0 +1,651 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: disco.proto
+
+package discopb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RecsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Defaults to 10.
+	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Ids to exclude from the results.
+	Exclude []string `protobuf:"bytes,3,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	// Ids to limit the results to.
+	Include       []string `protobuf:"bytes,4,rep,name=include,proto3" json:"include,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecsRequest) Reset() {
+	*x = RecsRequest{}
+	mi := &file_disco_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecsRequest) ProtoMessage() {}
+
+func (x *RecsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disco_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecsRequest.ProtoReflect.Descriptor instead.
+func (*RecsRequest) Descriptor() ([]byte, []int) {
+	return file_disco_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RecsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RecsRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RecsRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *RecsRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+type BatchRecsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Ids   []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// Defaults to 10.
+	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Ids to exclude from the results.
+	Exclude []string `protobuf:"bytes,3,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	// Ids to limit the results to.
+	Include       []string `protobuf:"bytes,4,rep,name=include,proto3" json:"include,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRecsRequest) Reset() {
+	*x = BatchRecsRequest{}
+	mi := &file_disco_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRecsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRecsRequest) ProtoMessage() {}
+
+func (x *BatchRecsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disco_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRecsRequest.ProtoReflect.Descriptor instead.
+func (*BatchRecsRequest) Descriptor() ([]byte, []int) {
+	return file_disco_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BatchRecsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BatchRecsRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *BatchRecsRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *BatchRecsRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+type Rec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Score         float32                `protobuf:"fixed32,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rec) Reset() {
+	*x = Rec{}
+	mi := &file_disco_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rec) ProtoMessage() {}
+
+func (x *Rec) ProtoReflect() protoreflect.Message {
+	mi := &file_disco_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rec.ProtoReflect.Descriptor instead.
+func (*Rec) Descriptor() ([]byte, []int) {
+	return file_disco_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Rec) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Rec) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type RecsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Recs  []*Rec                 `protobuf:"bytes,2,rep,name=recs,proto3" json:"recs,omitempty"`
+	// False for unknown ids in batch and stream requests.
+	Found         bool `protobuf:"varint,3,opt,name=found,proto3" json:"found,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecsResponse) Reset() {
+	*x = RecsResponse{}
+	mi := &file_disco_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecsResponse) ProtoMessage() {}
+
+func (x *RecsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_disco_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecsResponse.ProtoReflect.Descriptor instead.
+func (*RecsResponse) Descriptor() ([]byte, []int) {
+	return file_disco_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RecsResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RecsResponse) GetRecs() []*Rec {
+	if x != nil {
+		return x.Recs
+	}
+	return nil
+}
+
+func (x *RecsResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+type BatchRecsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*RecsResponse        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRecsResponse) Reset() {
+	*x = BatchRecsResponse{}
+	mi := &file_disco_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRecsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRecsResponse) ProtoMessage() {}
+
+func (x *BatchRecsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_disco_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRecsResponse.ProtoReflect.Descriptor instead.
+func (*BatchRecsResponse) Descriptor() ([]byte, []int) {
+	return file_disco_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchRecsResponse) GetResults() []*RecsResponse {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type PredictRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PredictRequest) Reset() {
+	*x = PredictRequest{}
+	mi := &file_disco_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PredictRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictRequest) ProtoMessage() {}
+
+func (x *PredictRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disco_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictRequest.ProtoReflect.Descriptor instead.
+func (*PredictRequest) Descriptor() ([]byte, []int) {
+	return file_disco_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PredictRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PredictRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+type PredictResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Score         float32                `protobuf:"fixed32,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PredictResponse) Reset() {
+	*x = PredictResponse{}
+	mi := &file_disco_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PredictResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictResponse) ProtoMessage() {}
+
+func (x *PredictResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_disco_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictResponse.ProtoReflect.Descriptor instead.
+func (*PredictResponse) Descriptor() ([]byte, []int) {
+	return file_disco_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PredictResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PredictResponse) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *PredictResponse) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type BatchPredictRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*PredictRequest      `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchPredictRequest) Reset() {
+	*x = BatchPredictRequest{}
+	mi := &file_disco_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPredictRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPredictRequest) ProtoMessage() {}
+
+func (x *BatchPredictRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disco_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPredictRequest.ProtoReflect.Descriptor instead.
+func (*BatchPredictRequest) Descriptor() ([]byte, []int) {
+	return file_disco_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchPredictRequest) GetRequests() []*PredictRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type BatchPredictResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Predictions   []*PredictResponse     `protobuf:"bytes,1,rep,name=predictions,proto3" json:"predictions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchPredictResponse) Reset() {
+	*x = BatchPredictResponse{}
+	mi := &file_disco_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPredictResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPredictResponse) ProtoMessage() {}
+
+func (x *BatchPredictResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_disco_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPredictResponse.ProtoReflect.Descriptor instead.
+func (*BatchPredictResponse) Descriptor() ([]byte, []int) {
+	return file_disco_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatchPredictResponse) GetPredictions() []*PredictResponse {
+	if x != nil {
+		return x.Predictions
+	}
+	return nil
+}
+
+var File_disco_proto protoreflect.FileDescriptor
+
+const file_disco_proto_rawDesc = "" +
+	"\n" +
+	"\vdisco.proto\x12\bdisco.v1\"g\n" +
+	"\vRecsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x18\n" +
+	"\aexclude\x18\x03 \x03(\tR\aexclude\x12\x18\n" +
+	"\ainclude\x18\x04 \x03(\tR\ainclude\"n\n" +
+	"\x10BatchRecsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x18\n" +
+	"\aexclude\x18\x03 \x03(\tR\aexclude\x12\x18\n" +
+	"\ainclude\x18\x04 \x03(\tR\ainclude\"+\n" +
+	"\x03Rec\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x02R\x05score\"W\n" +
+	"\fRecsResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\x04recs\x18\x02 \x03(\v2\r.disco.v1.RecR\x04recs\x12\x14\n" +
+	"\x05found\x18\x03 \x01(\bR\x05found\"E\n" +
+	"\x11BatchRecsResponse\x120\n" +
+	"\aresults\x18\x01 \x03(\v2\x16.disco.v1.RecsResponseR\aresults\"B\n" +
+	"\x0ePredictRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\"Y\n" +
+	"\x0fPredictResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x02R\x05score\"K\n" +
+	"\x13BatchPredictRequest\x124\n" +
+	"\brequests\x18\x01 \x03(\v2\x18.disco.v1.PredictRequestR\brequests\"S\n" +
+	"\x14BatchPredictResponse\x12;\n" +
+	"\vpredictions\x18\x01 \x03(\v2\x19.disco.v1.PredictResponseR\vpredictions2\x8f\x06\n" +
+	"\vRecommender\x129\n" +
+	"\bUserRecs\x12\x15.disco.v1.RecsRequest\x1a\x16.disco.v1.RecsResponse\x129\n" +
+	"\bItemRecs\x12\x15.disco.v1.RecsRequest\x1a\x16.disco.v1.RecsResponse\x12=\n" +
+	"\fSimilarUsers\x12\x15.disco.v1.RecsRequest\x1a\x16.disco.v1.RecsResponse\x12>\n" +
+	"\aPredict\x12\x18.disco.v1.PredictRequest\x1a\x19.disco.v1.PredictResponse\x12H\n" +
+	"\rBatchUserRecs\x12\x1a.disco.v1.BatchRecsRequest\x1a\x1b.disco.v1.BatchRecsResponse\x12H\n" +
+	"\rBatchItemRecs\x12\x1a.disco.v1.BatchRecsRequest\x1a\x1b.disco.v1.BatchRecsResponse\x12L\n" +
+	"\x11BatchSimilarUsers\x12\x1a.disco.v1.BatchRecsRequest\x1a\x1b.disco.v1.BatchRecsResponse\x12M\n" +
+	"\fBatchPredict\x12\x1d.disco.v1.BatchPredictRequest\x1a\x1e.disco.v1.BatchPredictResponse\x12F\n" +
+	"\x0eStreamUserRecs\x12\x1a.disco.v1.BatchRecsRequest\x1a\x16.disco.v1.RecsResponse0\x01\x12F\n" +
+	"\x0eStreamItemRecs\x12\x1a.disco.v1.BatchRecsRequest\x1a\x16.disco.v1.RecsResponse0\x01\x12J\n" +
+	"\x12StreamSimilarUsers\x12\x1a.disco.v1.BatchRecsRequest\x1a\x16.disco.v1.RecsResponse0\x01B/Z-github.com/ankane/disco-go/grpcserver/discopbb\x06proto3"
+
+var (
+	file_disco_proto_rawDescOnce sync.Once
+	file_disco_proto_rawDescData []byte
+)
+
+func file_disco_proto_rawDescGZIP() []byte {
+	file_disco_proto_rawDescOnce.Do(func() {
+		file_disco_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_disco_proto_rawDesc), len(file_disco_proto_rawDesc)))
+	})
+	return file_disco_proto_rawDescData
+}
+
+var file_disco_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_disco_proto_goTypes = []any{
+	(*RecsRequest)(nil),          // 0: disco.v1.RecsRequest
+	(*BatchRecsRequest)(nil),     // 1: disco.v1.BatchRecsRequest
+	(*Rec)(nil),                  // 2: disco.v1.Rec
+	(*RecsResponse)(nil),         // 3: disco.v1.RecsResponse
+	(*BatchRecsResponse)(nil),    // 4: disco.v1.BatchRecsResponse
+	(*PredictRequest)(nil),       // 5: disco.v1.PredictRequest
+	(*PredictResponse)(nil),      // 6: disco.v1.PredictResponse
+	(*BatchPredictRequest)(nil),  // 7: disco.v1.BatchPredictRequest
+	(*BatchPredictResponse)(nil), // 8: disco.v1.BatchPredictResponse
+}
+var file_disco_proto_depIdxs = []int32{
+	2,  // 0: disco.v1.RecsResponse.recs:type_name -> disco.v1.Rec
+	3,  // 1: disco.v1.BatchRecsResponse.results:type_name -> disco.v1.RecsResponse
+	5,  // 2: disco.v1.BatchPredictRequest.requests:type_name -> disco.v1.PredictRequest
+	6,  // 3: disco.v1.BatchPredictResponse.predictions:type_name -> disco.v1.PredictResponse
+	0,  // 4: disco.v1.Recommender.UserRecs:input_type -> disco.v1.RecsRequest
+	0,  // 5: disco.v1.Recommender.ItemRecs:input_type -> disco.v1.RecsRequest
+	0,  // 6: disco.v1.Recommender.SimilarUsers:input_type -> disco.v1.RecsRequest
+	5,  // 7: disco.v1.Recommender.Predict:input_type -> disco.v1.PredictRequest
+	1,  // 8: disco.v1.Recommender.BatchUserRecs:input_type -> disco.v1.BatchRecsRequest
+	1,  // 9: disco.v1.Recommender.BatchItemRecs:input_type -> disco.v1.BatchRecsRequest
+	1,  // 10: disco.v1.Recommender.BatchSimilarUsers:input_type -> disco.v1.BatchRecsRequest
+	7,  // 11: disco.v1.Recommender.BatchPredict:input_type -> disco.v1.BatchPredictRequest
+	1,  // 12: disco.v1.Recommender.StreamUserRecs:input_type -> disco.v1.BatchRecsRequest
+	1,  // 13: disco.v1.Recommender.StreamItemRecs:input_type -> disco.v1.BatchRecsRequest
+	1,  // 14: disco.v1.Recommender.StreamSimilarUsers:input_type -> disco.v1.BatchRecsRequest
+	3,  // 15: disco.v1.Recommender.UserRecs:output_type -> disco.v1.RecsResponse
+	3,  // 16: disco.v1.Recommender.ItemRecs:output_type -> disco.v1.RecsResponse
+	3,  // 17: disco.v1.Recommender.SimilarUsers:output_type -> disco.v1.RecsResponse
+	6,  // 18: disco.v1.Recommender.Predict:output_type -> disco.v1.PredictResponse
+	4,  // 19: disco.v1.Recommender.BatchUserRecs:output_type -> disco.v1.BatchRecsResponse
+	4,  // 20: disco.v1.Recommender.BatchItemRecs:output_type -> disco.v1.BatchRecsResponse
+	4,  // 21: disco.v1.Recommender.BatchSimilarUsers:output_type -> disco.v1.BatchRecsResponse
+	8,  // 22: disco.v1.Recommender.BatchPredict:output_type -> disco.v1.BatchPredictResponse
+	3,  // 23: disco.v1.Recommender.StreamUserRecs:output_type -> disco.v1.RecsResponse
+	3,  // 24: disco.v1.Recommender.StreamItemRecs:output_type -> disco.v1.RecsResponse
+	3,  // 25: disco.v1.Recommender.StreamSimilarUsers:output_type -> disco.v1.RecsResponse
+	15, // [15:26] is the sub-list for method output_type
+	4,  // [4:15] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_disco_proto_init() }
+func file_disco_proto_init() {
+	if File_disco_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_disco_proto_rawDesc), len(file_disco_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_disco_proto_goTypes,
+		DependencyIndexes: file_disco_proto_depIdxs,
+		MessageInfos:      file_disco_proto_msgTypes,
+	}.Build()
+	File_disco_proto = out.File
+	file_disco_proto_goTypes = nil
+	file_disco_proto_depIdxs = nil
+}
@@ -0,0 +1,541 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: disco.proto
+
+package discopb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Recommender_UserRecs_FullMethodName           = "/disco.v1.Recommender/UserRecs"
+	Recommender_ItemRecs_FullMethodName           = "/disco.v1.Recommender/ItemRecs"
+	Recommender_SimilarUsers_FullMethodName       = "/disco.v1.Recommender/SimilarUsers"
+	Recommender_Predict_FullMethodName            = "/disco.v1.Recommender/Predict"
+	Recommender_BatchUserRecs_FullMethodName      = "/disco.v1.Recommender/BatchUserRecs"
+	Recommender_BatchItemRecs_FullMethodName      = "/disco.v1.Recommender/BatchItemRecs"
+	Recommender_BatchSimilarUsers_FullMethodName  = "/disco.v1.Recommender/BatchSimilarUsers"
+	Recommender_BatchPredict_FullMethodName       = "/disco.v1.Recommender/BatchPredict"
+	Recommender_StreamUserRecs_FullMethodName     = "/disco.v1.Recommender/StreamUserRecs"
+	Recommender_StreamItemRecs_FullMethodName     = "/disco.v1.Recommender/StreamItemRecs"
+	Recommender_StreamSimilarUsers_FullMethodName = "/disco.v1.Recommender/StreamSimilarUsers"
+)
+
+// RecommenderClient is the client API for Recommender service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// A recommendation service.
+//
+// Ids are strings and are converted to the id types of the model.
+type RecommenderClient interface {
+	// Returns recommendations for a user.
+	UserRecs(ctx context.Context, in *RecsRequest, opts ...grpc.CallOption) (*RecsResponse, error)
+	// Returns recommendations for an item.
+	ItemRecs(ctx context.Context, in *RecsRequest, opts ...grpc.CallOption) (*RecsResponse, error)
+	// Returns similar users.
+	SimilarUsers(ctx context.Context, in *RecsRequest, opts ...grpc.CallOption) (*RecsResponse, error)
+	// Returns the predicted score for a user and item.
+	Predict(ctx context.Context, in *PredictRequest, opts ...grpc.CallOption) (*PredictResponse, error)
+	// Returns recommendations for multiple users.
+	BatchUserRecs(ctx context.Context, in *BatchRecsRequest, opts ...grpc.CallOption) (*BatchRecsResponse, error)
+	// Returns recommendations for multiple items.
+	BatchItemRecs(ctx context.Context, in *BatchRecsRequest, opts ...grpc.CallOption) (*BatchRecsResponse, error)
+	// Returns similar users for multiple users.
+	BatchSimilarUsers(ctx context.Context, in *BatchRecsRequest, opts ...grpc.CallOption) (*BatchRecsResponse, error)
+	// Returns predicted scores for multiple users and items.
+	BatchPredict(ctx context.Context, in *BatchPredictRequest, opts ...grpc.CallOption) (*BatchPredictResponse, error)
+	// Streams recommendations for multiple users.
+	StreamUserRecs(ctx context.Context, in *BatchRecsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RecsResponse], error)
+	// Streams recommendations for multiple items.
+	StreamItemRecs(ctx context.Context, in *BatchRecsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RecsResponse], error)
+	// Streams similar users for multiple users.
+	StreamSimilarUsers(ctx context.Context, in *BatchRecsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RecsResponse], error)
+}
+
+type recommenderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRecommenderClient(cc grpc.ClientConnInterface) RecommenderClient {
+	return &recommenderClient{cc}
+}
+
+func (c *recommenderClient) UserRecs(ctx context.Context, in *RecsRequest, opts ...grpc.CallOption) (*RecsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecsResponse)
+	err := c.cc.Invoke(ctx, Recommender_UserRecs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommenderClient) ItemRecs(ctx context.Context, in *RecsRequest, opts ...grpc.CallOption) (*RecsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecsResponse)
+	err := c.cc.Invoke(ctx, Recommender_ItemRecs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommenderClient) SimilarUsers(ctx context.Context, in *RecsRequest, opts ...grpc.CallOption) (*RecsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecsResponse)
+	err := c.cc.Invoke(ctx, Recommender_SimilarUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommenderClient) Predict(ctx context.Context, in *PredictRequest, opts ...grpc.CallOption) (*PredictResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PredictResponse)
+	err := c.cc.Invoke(ctx, Recommender_Predict_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommenderClient) BatchUserRecs(ctx context.Context, in *BatchRecsRequest, opts ...grpc.CallOption) (*BatchRecsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchRecsResponse)
+	err := c.cc.Invoke(ctx, Recommender_BatchUserRecs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommenderClient) BatchItemRecs(ctx context.Context, in *BatchRecsRequest, opts ...grpc.CallOption) (*BatchRecsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchRecsResponse)
+	err := c.cc.Invoke(ctx, Recommender_BatchItemRecs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommenderClient) BatchSimilarUsers(ctx context.Context, in *BatchRecsRequest, opts ...grpc.CallOption) (*BatchRecsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchRecsResponse)
+	err := c.cc.Invoke(ctx, Recommender_BatchSimilarUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommenderClient) BatchPredict(ctx context.Context, in *BatchPredictRequest, opts ...grpc.CallOption) (*BatchPredictResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchPredictResponse)
+	err := c.cc.Invoke(ctx, Recommender_BatchPredict_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommenderClient) StreamUserRecs(ctx context.Context, in *BatchRecsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RecsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Recommender_ServiceDesc.Streams[0], Recommender_StreamUserRecs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BatchRecsRequest, RecsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Recommender_StreamUserRecsClient = grpc.ServerStreamingClient[RecsResponse]
+
+func (c *recommenderClient) StreamItemRecs(ctx context.Context, in *BatchRecsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RecsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Recommender_ServiceDesc.Streams[1], Recommender_StreamItemRecs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BatchRecsRequest, RecsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Recommender_StreamItemRecsClient = grpc.ServerStreamingClient[RecsResponse]
+
+func (c *recommenderClient) StreamSimilarUsers(ctx context.Context, in *BatchRecsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RecsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Recommender_ServiceDesc.Streams[2], Recommender_StreamSimilarUsers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BatchRecsRequest, RecsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Recommender_StreamSimilarUsersClient = grpc.ServerStreamingClient[RecsResponse]
+
+// RecommenderServer is the server API for Recommender service.
+// All implementations must embed UnimplementedRecommenderServer
+// for forward compatibility.
+//
+// A recommendation service.
+//
+// Ids are strings and are converted to the id types of the model.
+type RecommenderServer interface {
+	// Returns recommendations for a user.
+	UserRecs(context.Context, *RecsRequest) (*RecsResponse, error)
+	// Returns recommendations for an item.
+	ItemRecs(context.Context, *RecsRequest) (*RecsResponse, error)
+	// Returns similar users.
+	SimilarUsers(context.Context, *RecsRequest) (*RecsResponse, error)
+	// Returns the predicted score for a user and item.
+	Predict(context.Context, *PredictRequest) (*PredictResponse, error)
+	// Returns recommendations for multiple users.
+	BatchUserRecs(context.Context, *BatchRecsRequest) (*BatchRecsResponse, error)
+	// Returns recommendations for multiple items.
+	BatchItemRecs(context.Context, *BatchRecsRequest) (*BatchRecsResponse, error)
+	// Returns similar users for multiple users.
+	BatchSimilarUsers(context.Context, *BatchRecsRequest) (*BatchRecsResponse, error)
+	// Returns predicted scores for multiple users and items.
+	BatchPredict(context.Context, *BatchPredictRequest) (*BatchPredictResponse, error)
+	// Streams recommendations for multiple users.
+	StreamUserRecs(*BatchRecsRequest, grpc.ServerStreamingServer[RecsResponse]) error
+	// Streams recommendations for multiple items.
+	StreamItemRecs(*BatchRecsRequest, grpc.ServerStreamingServer[RecsResponse]) error
+	// Streams similar users for multiple users.
+	StreamSimilarUsers(*BatchRecsRequest, grpc.ServerStreamingServer[RecsResponse]) error
+	mustEmbedUnimplementedRecommenderServer()
+}
+
+// UnimplementedRecommenderServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRecommenderServer struct{}
+
+func (UnimplementedRecommenderServer) UserRecs(context.Context, *RecsRequest) (*RecsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserRecs not implemented")
+}
+func (UnimplementedRecommenderServer) ItemRecs(context.Context, *RecsRequest) (*RecsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ItemRecs not implemented")
+}
+func (UnimplementedRecommenderServer) SimilarUsers(context.Context, *RecsRequest) (*RecsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimilarUsers not implemented")
+}
+func (UnimplementedRecommenderServer) Predict(context.Context, *PredictRequest) (*PredictResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Predict not implemented")
+}
+func (UnimplementedRecommenderServer) BatchUserRecs(context.Context, *BatchRecsRequest) (*BatchRecsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUserRecs not implemented")
+}
+func (UnimplementedRecommenderServer) BatchItemRecs(context.Context, *BatchRecsRequest) (*BatchRecsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchItemRecs not implemented")
+}
+func (UnimplementedRecommenderServer) BatchSimilarUsers(context.Context, *BatchRecsRequest) (*BatchRecsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchSimilarUsers not implemented")
+}
+func (UnimplementedRecommenderServer) BatchPredict(context.Context, *BatchPredictRequest) (*BatchPredictResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchPredict not implemented")
+}
+func (UnimplementedRecommenderServer) StreamUserRecs(*BatchRecsRequest, grpc.ServerStreamingServer[RecsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamUserRecs not implemented")
+}
+func (UnimplementedRecommenderServer) StreamItemRecs(*BatchRecsRequest, grpc.ServerStreamingServer[RecsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamItemRecs not implemented")
+}
+func (UnimplementedRecommenderServer) StreamSimilarUsers(*BatchRecsRequest, grpc.ServerStreamingServer[RecsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSimilarUsers not implemented")
+}
+func (UnimplementedRecommenderServer) mustEmbedUnimplementedRecommenderServer() {}
+func (UnimplementedRecommenderServer) testEmbeddedByValue()                     {}
+
+// UnsafeRecommenderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RecommenderServer will
+// result in compilation errors.
+type UnsafeRecommenderServer interface {
+	mustEmbedUnimplementedRecommenderServer()
+}
+
+func RegisterRecommenderServer(s grpc.ServiceRegistrar, srv RecommenderServer) {
+	// If the following call pancis, it indicates UnimplementedRecommenderServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Recommender_ServiceDesc, srv)
+}
+
+func _Recommender_UserRecs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommenderServer).UserRecs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Recommender_UserRecs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommenderServer).UserRecs(ctx, req.(*RecsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Recommender_ItemRecs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommenderServer).ItemRecs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Recommender_ItemRecs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommenderServer).ItemRecs(ctx, req.(*RecsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Recommender_SimilarUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommenderServer).SimilarUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Recommender_SimilarUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommenderServer).SimilarUsers(ctx, req.(*RecsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Recommender_Predict_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PredictRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommenderServer).Predict(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Recommender_Predict_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommenderServer).Predict(ctx, req.(*PredictRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Recommender_BatchUserRecs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRecsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommenderServer).BatchUserRecs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Recommender_BatchUserRecs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommenderServer).BatchUserRecs(ctx, req.(*BatchRecsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Recommender_BatchItemRecs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRecsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommenderServer).BatchItemRecs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Recommender_BatchItemRecs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommenderServer).BatchItemRecs(ctx, req.(*BatchRecsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Recommender_BatchSimilarUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRecsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommenderServer).BatchSimilarUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Recommender_BatchSimilarUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommenderServer).BatchSimilarUsers(ctx, req.(*BatchRecsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Recommender_BatchPredict_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchPredictRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommenderServer).BatchPredict(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Recommender_BatchPredict_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommenderServer).BatchPredict(ctx, req.(*BatchPredictRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Recommender_StreamUserRecs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchRecsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RecommenderServer).StreamUserRecs(m, &grpc.GenericServerStream[BatchRecsRequest, RecsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Recommender_StreamUserRecsServer = grpc.ServerStreamingServer[RecsResponse]
+
+func _Recommender_StreamItemRecs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchRecsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RecommenderServer).StreamItemRecs(m, &grpc.GenericServerStream[BatchRecsRequest, RecsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Recommender_StreamItemRecsServer = grpc.ServerStreamingServer[RecsResponse]
+
+func _Recommender_StreamSimilarUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchRecsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RecommenderServer).StreamSimilarUsers(m, &grpc.GenericServerStream[BatchRecsRequest, RecsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Recommender_StreamSimilarUsersServer = grpc.ServerStreamingServer[RecsResponse]
+
+// Recommender_ServiceDesc is the grpc.ServiceDesc for Recommender service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Recommender_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "disco.v1.Recommender",
+	HandlerType: (*RecommenderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UserRecs",
+			Handler:    _Recommender_UserRecs_Handler,
+		},
+		{
+			MethodName: "ItemRecs",
+			Handler:    _Recommender_ItemRecs_Handler,
+		},
+		{
+			MethodName: "SimilarUsers",
+			Handler:    _Recommender_SimilarUsers_Handler,
+		},
+		{
+			MethodName: "Predict",
+			Handler:    _Recommender_Predict_Handler,
+		},
+		{
+			MethodName: "BatchUserRecs",
+			Handler:    _Recommender_BatchUserRecs_Handler,
+		},
+		{
+			MethodName: "BatchItemRecs",
+			Handler:    _Recommender_BatchItemRecs_Handler,
+		},
+		{
+			MethodName: "BatchSimilarUsers",
+			Handler:    _Recommender_BatchSimilarUsers_Handler,
+		},
+		{
+			MethodName: "BatchPredict",
+			Handler:    _Recommender_BatchPredict_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamUserRecs",
+			Handler:       _Recommender_StreamUserRecs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamItemRecs",
+			Handler:       _Recommender_StreamItemRecs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamSimilarUsers",
+			Handler:       _Recommender_StreamSimilarUsers_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "disco.proto",
+}
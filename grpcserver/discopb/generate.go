@@ -0,0 +1,4 @@
+// Package discopb contains the protobuf definitions for the recommendation service.
+package discopb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative disco.proto
@@ -0,0 +1,16 @@
+module github.com/ankane/disco-go/grpcserver
+
+go 1.26
+
+require (
+	github.com/ankane/disco-go v0.0.0-20261018173821-85c5c440181f
+	google.golang.org/grpc v1.82.1
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	golang.org/x/net v0.53.0 // indirect
+	golang.org/x/sys v0.43.0 // indirect
+	golang.org/x/text v0.36.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260414002931-afd174a4e478 // indirect
+)
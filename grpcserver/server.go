@@ -0,0 +1,331 @@
+// Package grpcserver serves recommendations over gRPC.
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ankane/disco-go"
+	"github.com/ankane/disco-go/grpcserver/discopb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// A gRPC recommendation server.
+//
+// Register it with discopb.RegisterRecommenderServer.
+type Server[T disco.Id, U disco.Id] struct {
+	discopb.UnimplementedRecommenderServer
+
+	mu    sync.RWMutex
+	model *servedModel[T, U]
+}
+
+// a model with id sets to check if users and items exist
+type servedModel[T disco.Id, U disco.Id] struct {
+	disco.Model[T, U]
+	users map[T]bool
+	items map[U]bool
+}
+
+// Creates a server for a model.
+//
+// The model must not be modified while the server is running.
+func New[T disco.Id, U disco.Id](model disco.Model[T, U]) *Server[T, U] {
+	s := &Server[T, U]{}
+	s.SetModel(model)
+	return s
+}
+
+// Replaces the model.
+func (s *Server[T, U]) SetModel(model disco.Model[T, U]) {
+	served := &servedModel[T, U]{
+		Model: model,
+		users: idSet(model.UserIds()),
+		items: idSet(model.ItemIds()),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.model = served
+}
+
+func (s *Server[T, U]) currentModel() *servedModel[T, U] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model
+}
+
+type kind int
+
+const (
+	userRecs kind = iota
+	itemRecs
+	similarUsers
+)
+
+// Returns recommendations for a user.
+func (s *Server[T, U]) UserRecs(ctx context.Context, req *discopb.RecsRequest) (*discopb.RecsResponse, error) {
+	return s.one(userRecs, req)
+}
+
+// Returns recommendations for an item.
+func (s *Server[T, U]) ItemRecs(ctx context.Context, req *discopb.RecsRequest) (*discopb.RecsResponse, error) {
+	return s.one(itemRecs, req)
+}
+
+// Returns similar users.
+func (s *Server[T, U]) SimilarUsers(ctx context.Context, req *discopb.RecsRequest) (*discopb.RecsResponse, error) {
+	return s.one(similarUsers, req)
+}
+
+// Returns recommendations for multiple users.
+func (s *Server[T, U]) BatchUserRecs(ctx context.Context, req *discopb.BatchRecsRequest) (*discopb.BatchRecsResponse, error) {
+	return s.batch(userRecs, req)
+}
+
+// Returns recommendations for multiple items.
+func (s *Server[T, U]) BatchItemRecs(ctx context.Context, req *discopb.BatchRecsRequest) (*discopb.BatchRecsResponse, error) {
+	return s.batch(itemRecs, req)
+}
+
+// Returns similar users for multiple users.
+func (s *Server[T, U]) BatchSimilarUsers(ctx context.Context, req *discopb.BatchRecsRequest) (*discopb.BatchRecsResponse, error) {
+	return s.batch(similarUsers, req)
+}
+
+// Streams recommendations for multiple users.
+func (s *Server[T, U]) StreamUserRecs(req *discopb.BatchRecsRequest, stream grpc.ServerStreamingServer[discopb.RecsResponse]) error {
+	return s.stream(userRecs, req, stream)
+}
+
+// Streams recommendations for multiple items.
+func (s *Server[T, U]) StreamItemRecs(req *discopb.BatchRecsRequest, stream grpc.ServerStreamingServer[discopb.RecsResponse]) error {
+	return s.stream(itemRecs, req, stream)
+}
+
+// Streams similar users for multiple users.
+func (s *Server[T, U]) StreamSimilarUsers(req *discopb.BatchRecsRequest, stream grpc.ServerStreamingServer[discopb.RecsResponse]) error {
+	return s.stream(similarUsers, req, stream)
+}
+
+// Returns the predicted score for a user and item.
+func (s *Server[T, U]) Predict(ctx context.Context, req *discopb.PredictRequest) (*discopb.PredictResponse, error) {
+	return predict(s.currentModel(), req)
+}
+
+// Returns predicted scores for multiple users and items.
+func (s *Server[T, U]) BatchPredict(ctx context.Context, req *discopb.BatchPredictRequest) (*discopb.BatchPredictResponse, error) {
+	model := s.currentModel()
+	predictions := make([]*discopb.PredictResponse, 0, len(req.Requests))
+	for _, r := range req.Requests {
+		prediction, err := predict(model, r)
+		if err != nil {
+			return nil, err
+		}
+		predictions = append(predictions, prediction)
+	}
+	return &discopb.BatchPredictResponse{Predictions: predictions}, nil
+}
+
+func predict[T disco.Id, U disco.Id](model *servedModel[T, U], req *discopb.PredictRequest) (*discopb.PredictResponse, error) {
+	userId, err := disco.ParseId[T](req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid user id")
+	}
+
+	itemId, err := disco.ParseId[U](req.ItemId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid item id")
+	}
+
+	return &discopb.PredictResponse{UserId: req.UserId, ItemId: req.ItemId, Score: model.Predict(userId, itemId)}, nil
+}
+
+func (s *Server[T, U]) one(k kind, req *discopb.RecsRequest) (*discopb.RecsResponse, error) {
+	recs, err := s.recommender(k, req.Count, req.Exclude, req.Include)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := recs(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Found {
+		if k == itemRecs {
+			return nil, status.Error(codes.NotFound, "Item not found")
+		}
+		return nil, status.Error(codes.NotFound, "User not found")
+	}
+	return res, nil
+}
+
+func (s *Server[T, U]) batch(k kind, req *discopb.BatchRecsRequest) (*discopb.BatchRecsResponse, error) {
+	recs, err := s.recommender(k, req.Count, req.Exclude, req.Include)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*discopb.RecsResponse, 0, len(req.Ids))
+	for _, id := range req.Ids {
+		res, err := recs(id)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return &discopb.BatchRecsResponse{Results: results}, nil
+}
+
+func (s *Server[T, U]) stream(k kind, req *discopb.BatchRecsRequest, stream grpc.ServerStreamingServer[discopb.RecsResponse]) error {
+	recs, err := s.recommender(k, req.Count, req.Exclude, req.Include)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range req.Ids {
+		res, err := recs(id)
+		if err != nil {
+			return err
+		}
+		err = stream.Send(res)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parses the filter once for all ids in a request
+func (s *Server[T, U]) recommender(k kind, count int32, exclude []string, include []string) (func(id string) (*discopb.RecsResponse, error), error) {
+	model := s.currentModel()
+
+	switch k {
+	case userRecs:
+		f, err := newFilter[U](count, exclude, include, len(model.ItemIds()))
+		if err != nil {
+			return nil, err
+		}
+		return func(id string) (*discopb.RecsResponse, error) {
+			userId, err := disco.ParseId[T](id)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, "Invalid user id")
+			}
+			if !model.users[userId] {
+				return &discopb.RecsResponse{Id: id}, nil
+			}
+			return &discopb.RecsResponse{Id: id, Recs: f.apply(model.UserRecs(userId, f.limit())), Found: true}, nil
+		}, nil
+	case itemRecs:
+		f, err := newFilter[U](count, exclude, include, len(model.ItemIds()))
+		if err != nil {
+			return nil, err
+		}
+		return func(id string) (*discopb.RecsResponse, error) {
+			itemId, err := disco.ParseId[U](id)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, "Invalid item id")
+			}
+			if !model.items[itemId] {
+				return &discopb.RecsResponse{Id: id}, nil
+			}
+			return &discopb.RecsResponse{Id: id, Recs: f.apply(model.ItemRecs(itemId, f.limit())), Found: true}, nil
+		}, nil
+	default:
+		f, err := newFilter[T](count, exclude, include, len(model.UserIds()))
+		if err != nil {
+			return nil, err
+		}
+		return func(id string) (*discopb.RecsResponse, error) {
+			userId, err := disco.ParseId[T](id)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, "Invalid user id")
+			}
+			if !model.users[userId] {
+				return &discopb.RecsResponse{Id: id}, nil
+			}
+			return &discopb.RecsResponse{Id: id, Recs: f.apply(model.SimilarUsers(userId, f.limit())), Found: true}, nil
+		}, nil
+	}
+}
+
+// count, exclude, and include fields
+type filter[T disco.Id] struct {
+	count   int
+	total   int
+	exclude map[T]bool
+	include map[T]bool
+}
+
+const defaultCount = 10
+
+func newFilter[T disco.Id](count int32, exclude []string, include []string, total int) (*filter[T], error) {
+	if count < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid count")
+	}
+	f := &filter[T]{count: int(count), total: total}
+	if f.count == 0 {
+		f.count = defaultCount
+	}
+	// limit allocations
+	f.count = min(f.count, total)
+
+	var err error
+	f.exclude, err = parseIds[T](exclude)
+	if err != nil {
+		return nil, err
+	}
+	f.include, err = parseIds[T](include)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func idSet[T disco.Id](ids []T) map[T]bool {
+	set := make(map[T]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func parseIds[T disco.Id](values []string) (map[T]bool, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	ids := make(map[T]bool, len(values))
+	for _, v := range values {
+		id, err := disco.ParseId[T](v)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid id")
+		}
+		ids[id] = true
+	}
+	return ids, nil
+}
+
+// fetches all recommendations when filtering
+func (f *filter[T]) limit() int {
+	if f.exclude != nil || f.include != nil {
+		return f.total
+	}
+	return f.count
+}
+
+func (f *filter[T]) apply(recs []disco.Rec[T]) []*discopb.Rec {
+	res := make([]*discopb.Rec, 0, min(len(recs), f.count))
+	for _, rec := range recs {
+		if f.exclude[rec.Id] || (f.include != nil && !f.include[rec.Id]) {
+			continue
+		}
+		res = append(res, &discopb.Rec{Id: fmt.Sprint(rec.Id), Score: rec.Score})
+		if len(res) == f.count {
+			break
+		}
+	}
+	return res
+}
@@ -0,0 +1,254 @@
+package grpcserver_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/ankane/disco-go"
+	"github.com/ankane/disco-go/grpcserver"
+	"github.com/ankane/disco-go/grpcserver/discopb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func assertEqual[T comparable](t *testing.T, exp T, act T) {
+	if act != exp {
+		t.Errorf("Failed")
+	}
+}
+
+func assertDeepEqual[T any](t *testing.T, exp T, act T) {
+	if !reflect.DeepEqual(act, exp) {
+		t.Errorf("Failed")
+	}
+}
+
+func assertNil[T any](t *testing.T, act T) {
+	if !reflect.DeepEqual(act, nil) {
+		t.Errorf("Failed")
+	}
+}
+
+func assertCode(t *testing.T, err error, code codes.Code, message string) {
+	s, ok := status.FromError(err)
+	if !ok || s.Code() != code || s.Message() != message {
+		t.Errorf("Failed")
+	}
+}
+
+func fitModel(t *testing.T) *disco.Recommender[int, string] {
+	data := disco.NewDataset[int, string]()
+	for u := range 20 {
+		for i := range 10 {
+			if (u+i)%3 != 0 {
+				data.Push(u, fmt.Sprintf("item%d", i), 1.0)
+			}
+		}
+	}
+	recommender, err := disco.FitImplicit(data, disco.Seed(42))
+	assertNil(t, err)
+	return recommender
+}
+
+func newClient(t *testing.T, model disco.Model[int, string]) discopb.RecommenderClient {
+	listener := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	discopb.RegisterRecommenderServer(s, grpcserver.New(model))
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assertNil(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return discopb.NewRecommenderClient(conn)
+}
+
+func recIds(recs []*discopb.Rec) []string {
+	ids := []string{}
+	for _, rec := range recs {
+		ids = append(ids, rec.Id)
+	}
+	return ids
+}
+
+func TestUserRecs(t *testing.T) {
+	model := fitModel(t)
+	client := newClient(t, model)
+	ctx := context.Background()
+
+	res, err := client.UserRecs(ctx, &discopb.RecsRequest{Id: "1", Count: 2})
+	assertNil(t, err)
+	assertEqual(t, "1", res.Id)
+	assertEqual(t, true, res.Found)
+	expected := []string{}
+	for _, rec := range model.UserRecs(1, 2) {
+		expected = append(expected, rec.Id)
+	}
+	assertDeepEqual(t, expected, recIds(res.Recs))
+
+	res, err = client.UserRecs(ctx, &discopb.RecsRequest{Id: "1", Exclude: expected[:1]})
+	assertNil(t, err)
+	assertEqual(t, expected[1], res.Recs[0].Id)
+
+	_, err = client.UserRecs(ctx, &discopb.RecsRequest{Id: "100"})
+	assertCode(t, err, codes.NotFound, "User not found")
+
+	_, err = client.UserRecs(ctx, &discopb.RecsRequest{Id: "a"})
+	assertCode(t, err, codes.InvalidArgument, "Invalid user id")
+
+	_, err = client.UserRecs(ctx, &discopb.RecsRequest{Id: "1", Count: -1})
+	assertCode(t, err, codes.InvalidArgument, "Invalid count")
+}
+
+func TestLargeCount(t *testing.T) {
+	client := newClient(t, fitModel(t))
+	ctx := context.Background()
+
+	res, err := client.UserRecs(ctx, &discopb.RecsRequest{Id: "1", Count: math.MaxInt32})
+	assertNil(t, err)
+	assertEqual(t, 3, len(res.Recs))
+
+	res, err = client.ItemRecs(ctx, &discopb.RecsRequest{Id: "item1", Count: math.MaxInt32})
+	assertNil(t, err)
+	assertEqual(t, 9, len(res.Recs))
+}
+
+func TestBaseline(t *testing.T) {
+	data := disco.NewDataset[int, string]()
+	data.Push(1, "A", 1.0)
+	data.Push(1, "B", 1.0)
+	data.Push(2, "B", 1.0)
+	baseline, err := disco.FitPopular(data)
+	assertNil(t, err)
+	client := newClient(t, baseline)
+	ctx := context.Background()
+
+	res, err := client.UserRecs(ctx, &discopb.RecsRequest{Id: "2"})
+	assertNil(t, err)
+	assertDeepEqual(t, []string{"A"}, recIds(res.Recs))
+
+	_, err = client.UserRecs(ctx, &discopb.RecsRequest{Id: "3"})
+	assertCode(t, err, codes.NotFound, "User not found")
+
+	_, err = client.ItemRecs(ctx, &discopb.RecsRequest{Id: "C"})
+	assertCode(t, err, codes.NotFound, "Item not found")
+}
+
+func TestItemRecs(t *testing.T) {
+	client := newClient(t, fitModel(t))
+	ctx := context.Background()
+
+	res, err := client.ItemRecs(ctx, &discopb.RecsRequest{Id: "item1"})
+	assertNil(t, err)
+	assertEqual(t, 9, len(res.Recs))
+
+	res, err = client.ItemRecs(ctx, &discopb.RecsRequest{Id: "item1", Include: []string{"item2", "item3"}})
+	assertNil(t, err)
+	assertEqual(t, 2, len(res.Recs))
+
+	_, err = client.ItemRecs(ctx, &discopb.RecsRequest{Id: "missing"})
+	assertCode(t, err, codes.NotFound, "Item not found")
+}
+
+func TestSimilarUsers(t *testing.T) {
+	client := newClient(t, fitModel(t))
+
+	res, err := client.SimilarUsers(context.Background(), &discopb.RecsRequest{Id: "1", Count: 5})
+	assertNil(t, err)
+	assertEqual(t, 5, len(res.Recs))
+}
+
+func TestPredict(t *testing.T) {
+	model := fitModel(t)
+	client := newClient(t, model)
+	ctx := context.Background()
+
+	res, err := client.Predict(ctx, &discopb.PredictRequest{UserId: "1", ItemId: "item2"})
+	assertNil(t, err)
+	assertEqual(t, model.Predict(1, "item2"), res.Score)
+
+	batch, err := client.BatchPredict(ctx, &discopb.BatchPredictRequest{Requests: []*discopb.PredictRequest{
+		{UserId: "1", ItemId: "item2"},
+		{UserId: "2", ItemId: "item3"},
+	}})
+	assertNil(t, err)
+	assertEqual(t, 2, len(batch.Predictions))
+	assertEqual(t, model.Predict(2, "item3"), batch.Predictions[1].Score)
+
+	_, err = client.Predict(ctx, &discopb.PredictRequest{UserId: "a", ItemId: "item2"})
+	assertCode(t, err, codes.InvalidArgument, "Invalid user id")
+}
+
+func TestBatch(t *testing.T) {
+	client := newClient(t, fitModel(t))
+
+	res, err := client.BatchUserRecs(context.Background(), &discopb.BatchRecsRequest{Ids: []string{"1", "100", "2"}, Count: 3})
+	assertNil(t, err)
+	assertEqual(t, 3, len(res.Results))
+	assertEqual(t, true, res.Results[0].Found)
+	assertEqual(t, 3, len(res.Results[0].Recs))
+	assertEqual(t, false, res.Results[1].Found)
+	assertEqual(t, 0, len(res.Results[1].Recs))
+	assertEqual(t, "2", res.Results[2].Id)
+
+	items, err := client.BatchItemRecs(context.Background(), &discopb.BatchRecsRequest{Ids: []string{"item1", "item2"}})
+	assertNil(t, err)
+	assertEqual(t, 2, len(items.Results))
+
+	users, err := client.BatchSimilarUsers(context.Background(), &discopb.BatchRecsRequest{Ids: []string{"1"}})
+	assertNil(t, err)
+	assertEqual(t, 1, len(users.Results))
+}
+
+func TestStream(t *testing.T) {
+	client := newClient(t, fitModel(t))
+
+	ids := []string{}
+	for u := range 20 {
+		ids = append(ids, fmt.Sprint(u))
+	}
+
+	stream, err := client.StreamUserRecs(context.Background(), &discopb.BatchRecsRequest{Ids: ids, Count: 2})
+	assertNil(t, err)
+
+	received := []string{}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assertNil(t, err)
+		assertEqual(t, 2, len(res.Recs))
+		received = append(received, res.Id)
+	}
+	assertDeepEqual(t, ids, received)
+
+	stream2, err := client.StreamItemRecs(context.Background(), &discopb.BatchRecsRequest{Ids: []string{"item1", "bad"}})
+	assertNil(t, err)
+	res, err := stream2.Recv()
+	assertNil(t, err)
+	assertEqual(t, true, res.Found)
+	res, err = stream2.Recv()
+	assertNil(t, err)
+	assertEqual(t, false, res.Found)
+
+	stream3, err := client.StreamSimilarUsers(context.Background(), &discopb.BatchRecsRequest{Ids: []string{"a"}})
+	assertNil(t, err)
+	_, err = stream3.Recv()
+	assertCode(t, err, codes.InvalidArgument, "Invalid user id")
+}
@@ -15,6 +15,12 @@ func assertEqual[T comparable](t *testing.T, exp T, act T) {
 	}
 }
 
+func assertTrue(t *testing.T, act bool) {
+	if !act {
+		t.Errorf("Failed")
+	}
+}
+
 func assertDeepEqual[T any](t *testing.T, exp T, act T) {
 	if !reflect.DeepEqual(act, exp) {
 		t.Errorf("Failed")
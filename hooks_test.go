@@ -0,0 +1,16 @@
+package disco
+
+import "testing"
+
+// lets download tests serve fixture files
+func SetChecksum(t *testing.T, filename string, checksum string) {
+	old, ok := checksums[filename]
+	checksums[filename] = checksum
+	t.Cleanup(func() {
+		if ok {
+			checksums[filename] = old
+		} else {
+			delete(checksums, filename)
+		}
+	})
+}
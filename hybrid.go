@@ -0,0 +1,205 @@
+package disco
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+type sample struct {
+	user int
+	item int
+	// the rating for explicit feedback or 1 or 0 for implicit feedback
+	preference float32
+	weight     float32
+}
+
+type featureRow struct {
+	index int
+	value float32
+}
+
+// learns embeddings for features and sums them to get factors
+// https://arxiv.org/abs/1507.08439
+func fitHybrid[T Id, U Id](r *Recommender[T, U], samples []sample, validSet *Dataset[T, U], implicit bool, userFeatures *Features[T], itemFeatures *Features[U], config *config, rng *rand.Rand, endRange float32) {
+	users := len(r.userIds)
+	items := len(r.itemIds)
+
+	var userRows [][]featureRow
+	var userFeatureMap map[string]int
+	r.userIds, userRows, userFeatureMap = featureRows(r.userMap, r.userIds, userFeatures)
+	userFeatureCount := users + len(userFeatureMap)
+	for len(r.rated) < len(r.userIds) {
+		r.rated = append(r.rated, make(map[int]bool, 0))
+	}
+
+	var itemRows [][]featureRow
+	var itemFeatureMap map[string]int
+	r.itemIds, itemRows, itemFeatureMap = featureRows(r.itemMap, r.itemIds, itemFeatures)
+	itemFeatureCount := items + len(itemFeatureMap)
+
+	factors := config.factors
+	learningRate := config.learningRate
+	var lambda float32
+	if config.regularization != nil {
+		lambda = *config.regularization
+	} else if implicit {
+		lambda = 0.01
+	} else {
+		lambda = 0.1
+	}
+
+	userEmbeddings := createFactors(userFeatureCount, factors, rng, endRange)
+	itemEmbeddings := createFactors(itemFeatureCount, factors, rng, endRange)
+
+	userG := make([]float32, userFeatureCount)
+	for i := range userG {
+		userG[i] = 1.0
+	}
+	itemG := make([]float32, itemFeatureCount)
+	for i := range itemG {
+		itemG[i] = 1.0
+	}
+
+	pu := make([]float32, factors)
+	qi := make([]float32, factors)
+	grad := make([]float32, factors)
+
+	// adagrad
+	// accumulate before updating so large confidences cannot cause large steps
+	update := func(rows []featureRow, embeddings *matrix, g []float32, other []float32, e float32) {
+		for _, row := range rows {
+			x := embeddings.Row(row.index)
+			var gHat float32 = 0.0
+			for d := range x {
+				grad[d] = -e*row.value*other[d] + lambda*x[d]
+				gHat += grad[d] * grad[d]
+			}
+			g[row.index] += gHat / float32(factors)
+
+			nu := learningRate / sqrt(g[row.index])
+			scaledAdd(x, -nu, grad)
+		}
+	}
+
+	step := func(u int, i int, value float32, weight float32) float32 {
+		combineFeatures(userRows[u], userEmbeddings, pu)
+		combineFeatures(itemRows[i], itemEmbeddings, qi)
+		e := value - dot(pu, qi)
+		update(userRows[u], userEmbeddings, userG, qi, weight*e)
+		update(itemRows[i], itemEmbeddings, itemG, pu, weight*e)
+		return e
+	}
+
+	for iteration := 0; iteration < config.iterations; iteration++ {
+		var trainLoss float32 = 0.0
+
+		rng.Shuffle(len(samples), func(i, j int) {
+			samples[i], samples[j] = samples[j], samples[i]
+		})
+
+		for _, s := range samples {
+			e := step(s.user, s.item, s.preference, s.weight)
+			trainLoss += e * e
+
+			if implicit {
+				// sample an unrated item as a negative
+				j := rng.IntN(items)
+				if !r.rated[s.user][j] {
+					step(s.user, j, 0.0, 1.0)
+				}
+			}
+		}
+
+		if config.callback != nil {
+			info := FitInfo{
+				Iteration: iteration + 1,
+				TrainLoss: float32(math.NaN()),
+				ValidLoss: float32(math.NaN()),
+			}
+			if !implicit {
+				info.TrainLoss = sqrt(trainLoss / float32(len(samples)))
+				if validSet != nil {
+					r.userFactors = combineAllFeatures(userRows, userEmbeddings)
+					r.itemFactors = combineAllFeatures(itemRows, itemEmbeddings)
+					info.ValidLoss = r.Rmse(validSet)
+				} else {
+					info.ValidLoss = 0.0
+				}
+			}
+			config.callback(info)
+		}
+	}
+
+	r.userFactors = combineAllFeatures(userRows, userEmbeddings)
+	r.itemFactors = combineAllFeatures(itemRows, itemEmbeddings)
+	r.itemFeatureMap = itemFeatureMap
+	r.itemFeatureFactors = itemEmbeddings
+}
+
+// ids with interactions get an identity feature
+// and ids that only have features are added to the map
+func featureRows[T Id](idMap map[T]int, ids []T, features *Features[T]) ([]T, [][]featureRow, map[string]int) {
+	identities := len(ids)
+
+	rows := make([][]featureRow, identities)
+	for i := range identities {
+		rows[i] = []featureRow{{index: i, value: 1.0}}
+	}
+
+	featureMap := make(map[string]int, 0)
+	if features != nil {
+		for _, f := range features.data {
+			i, ok := idMap[f.id]
+			if !ok {
+				i = len(ids)
+				idMap[f.id] = i
+				ids = append(ids, f.id)
+				rows = append(rows, []featureRow{})
+			}
+
+			j, ok := featureMap[f.name]
+			if !ok {
+				j = identities + len(featureMap)
+				featureMap[f.name] = j
+			}
+
+			rows[i] = append(rows[i], featureRow{index: j, value: f.value})
+		}
+	}
+
+	for _, row := range rows {
+		normalizeFeatures(row)
+	}
+
+	return ids, rows, featureMap
+}
+
+// so each row sums to one
+func normalizeFeatures(row []featureRow) {
+	var sum float32 = 0.0
+	for _, f := range row {
+		sum += float32(math.Abs(float64(f.value)))
+	}
+	if sum > 0 {
+		for j := range row {
+			row[j].value /= sum
+		}
+	}
+}
+
+func combineFeatures(rows []featureRow, embeddings *matrix, out []float32) {
+	for d := range out {
+		out[d] = 0.0
+	}
+	for _, row := range rows {
+		scaledAdd(out, row.value, embeddings.Row(row.index))
+	}
+}
+
+func combineAllFeatures(rows [][]featureRow, embeddings *matrix) *matrix {
+	m := newMatrix(len(rows), embeddings.cols)
+	for i, row := range rows {
+		combineFeatures(row, embeddings, m.Row(i))
+	}
+	return m
+}
@@ -0,0 +1,81 @@
+package disco_test
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ankane/disco-go"
+)
+
+func hybridData() (*disco.Dataset[int, string], *disco.Features[string]) {
+	data := disco.NewDataset[int, string]()
+	features := disco.NewFeatures[string]()
+	for i := range 10 {
+		features.Push(fmt.Sprintf("comedy%d", i), "genre:comedy", 1.0)
+		features.Push(fmt.Sprintf("drama%d", i), "genre:drama", 1.0)
+	}
+	for u := range 40 {
+		genre := "comedy"
+		if u%2 == 1 {
+			genre = "drama"
+		}
+		for i := range 10 {
+			if (u+i)%3 != 0 {
+				data.Push(u, fmt.Sprintf("%s%d", genre, i), 1.0)
+			}
+		}
+	}
+	features.Push("new comedy", "genre:comedy", 1.0)
+	return data, features
+}
+
+func TestHybridImplicit(t *testing.T) {
+	data, features := hybridData()
+
+	recommender, err := disco.FitImplicit(data, disco.ItemFeatures(features), disco.Seed(42))
+	assertNil(t, err)
+
+	assertEqual(t, 21, len(recommender.ItemIds()))
+	assertEqual(t, 8, len(recommender.ItemFactors("new comedy")))
+
+	recs := recommender.ItemRecs("new comedy", 5)
+	assertEqual(t, 5, len(recs))
+	for _, id := range getIds(recs) {
+		assertTrue(t, strings.HasPrefix(id, "comedy"))
+	}
+
+	itemIds := getIds(recommender.UserRecs(0, 5))
+	sort.Strings(itemIds)
+	assertDeepEqual(t, []string{"comedy0", "comedy3", "comedy6", "comedy9", "new comedy"}, itemIds)
+}
+
+func TestHybridExplicit(t *testing.T) {
+	data, features := hybridData()
+	userFeatures := disco.NewFeatures[int]()
+	userFeatures.Push(100, "likes:comedy", 1.0)
+
+	recommender, err := disco.FitExplicit(data, disco.ItemFeatures(features), disco.UserFeatures(userFeatures))
+	assertNil(t, err)
+
+	assertEqual(t, 41, len(recommender.UserIds()))
+	assertEqual(t, 21, len(recommender.UserRecs(100, 25)))
+}
+
+func TestHybridWrongType(t *testing.T) {
+	data, _ := hybridData()
+	features := disco.NewFeatures[int]()
+
+	_, err := disco.FitImplicit(data, disco.ItemFeatures(features))
+	assertError(t, err, "Item features must have the same id type as items")
+}
+
+func TestHybridInferItem(t *testing.T) {
+	data, features := hybridData()
+
+	recommender, err := disco.FitImplicit(data, disco.ItemFeatures(features), disco.Seed(42))
+	assertNil(t, err)
+
+	assertDeepEqual(t, recommender.ItemFactors("new comedy"), recommender.InferItem(map[string]float32{"genre:comedy": 1.0}))
+}
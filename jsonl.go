@@ -0,0 +1,61 @@
+package disco
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+type jsonRating[T Id, U Id] struct {
+	UserId T         `json:"user_id"`
+	ItemId U         `json:"item_id"`
+	Value  float32   `json:"value"`
+	Time   time.Time `json:"time,omitzero"`
+}
+
+// Reads a dataset from a JSON Lines file.
+//
+// Each line has user_id, item_id, value, and optionally time keys.
+func ReadJSONL[T Id, U Id](r io.Reader) (*Dataset[T, U], error) {
+	data := NewDataset[T, U]()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var v jsonRating[T, U]
+		err := json.Unmarshal(scanner.Bytes(), &v)
+		if err != nil {
+			return nil, fmt.Errorf("Line %d: %w", line, err)
+		}
+
+		data.PushAt(v.UserId, v.ItemId, v.Value, v.Time)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Writes the dataset to a JSON Lines file.
+func (d *Dataset[T, U]) WriteJSONL(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+	for _, rating := range d.data {
+		err := encoder.Encode(jsonRating[T, U]{UserId: rating.userId, ItemId: rating.itemId, Value: rating.value, Time: rating.time})
+		if err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,39 @@
+package disco_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ankane/disco-go"
+)
+
+func TestJSONL(t *testing.T) {
+	data := disco.NewDataset[int, string]()
+	data.Push(1, "A", 5.0)
+	data.PushAt(1, "B", 3.5, time.Date(2025, 1, 1, 12, 30, 0, 0, time.UTC))
+
+	var b strings.Builder
+	err := data.WriteJSONL(&b)
+	assertNil(t, err)
+
+	expected := `{"user_id":1,"item_id":"A","value":5}
+{"user_id":1,"item_id":"B","value":3.5,"time":"2025-01-01T12:30:00Z"}
+`
+	assertEqual(t, expected, b.String())
+
+	data2, err := disco.ReadJSONL[int, string](strings.NewReader(b.String()))
+	assertNil(t, err)
+	assertEqual(t, 2, data2.Len())
+
+	var b2 strings.Builder
+	err = data2.WriteJSONL(&b2)
+	assertNil(t, err)
+	assertEqual(t, expected, b2.String())
+}
+
+func TestReadJSONLError(t *testing.T) {
+	input := "{\"user_id\":1,\"item_id\":\"A\",\"value\":1}\n\n{\"user_id\":\"b\"}\n"
+	_, err := disco.ReadJSONL[int, string](strings.NewReader(input))
+	assertError(t, err, "Line 3: json: cannot unmarshal string into Go struct field jsonRating[int,string].user_id of type int")
+}
@@ -33,14 +33,12 @@ func (m *matrix) Norms() []float32 {
 	res := make([]float32, 0, m.rows)
 
 	for i := 0; i < m.rows; i++ {
-		row := m.Row(i)
-		var norm float32 = 0.0
-		for j := range row {
-			norm += row[j] * row[j]
-		}
-		norm = sqrt(norm)
-		res = append(res, norm)
+		res = append(res, norm(m.Row(i)))
 	}
 
 	return res
 }
+
+func norm(x []float32) float32 {
+	return sqrt(dot(x, x))
+}
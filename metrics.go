@@ -0,0 +1,156 @@
+package disco
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// An evaluation metric.
+type Metric struct {
+	name string
+	k    int
+}
+
+// Root mean square error.
+func Rmse() Metric {
+	return Metric{name: "rmse"}
+}
+
+// Precision for the top k recommendations.
+func PrecisionAt(k int) Metric {
+	return Metric{name: "precision", k: k}
+}
+
+// Recall for the top k recommendations.
+func RecallAt(k int) Metric {
+	return Metric{name: "recall", k: k}
+}
+
+// Normalized discounted cumulative gain for the top k recommendations.
+func NdcgAt(k int) Metric {
+	return Metric{name: "ndcg", k: k}
+}
+
+// Mean average precision for the top k recommendations.
+func MapAt(k int) Metric {
+	return Metric{name: "map", k: k}
+}
+
+// Parses a metric name like rmse or ndcg@10.
+func ParseMetric(s string) (Metric, error) {
+	name, k, found := strings.Cut(s, "@")
+	metric := Metric{name: name}
+	if found {
+		v, err := strconv.Atoi(k)
+		if err != nil {
+			return Metric{}, fmt.Errorf("Invalid metric: %s", s)
+		}
+		metric.k = v
+	}
+	if !metric.valid() {
+		return Metric{}, fmt.Errorf("Invalid metric: %s", s)
+	}
+	return metric, nil
+}
+
+// Returns the name of the metric.
+func (m Metric) String() string {
+	if m.k > 0 {
+		return fmt.Sprintf("%s@%d", m.name, m.k)
+	}
+	return m.name
+}
+
+func (m Metric) higherIsBetter() bool {
+	return m.name != "rmse"
+}
+
+func (m Metric) valid() bool {
+	switch m.name {
+	case "rmse":
+		return m.k == 0
+	case "precision", "recall", "ndcg", "map":
+		return m.k > 0
+	default:
+		return false
+	}
+}
+
+// Evaluates a model on a validation set.
+//
+// For ranking metrics, ratings with positive values are relevant,
+// and scores are averaged over users with relevant items.
+func Evaluate[T Id, U Id](model Model[T, U], validSet *Dataset[T, U], metric Metric) float32 {
+	if !metric.valid() {
+		return float32(math.NaN())
+	}
+
+	if metric.name == "rmse" {
+		var sum float32 = 0.0
+		for _, v := range validSet.data {
+			diff := model.Predict(v.userId, v.itemId) - v.value
+			sum += diff * diff
+		}
+		return sqrt(sum / float32(len(validSet.data)))
+	}
+
+	relevant := make(map[T]map[U]bool)
+	userIds := make([]T, 0)
+	for _, v := range validSet.data {
+		if v.value <= 0 {
+			continue
+		}
+		items, ok := relevant[v.userId]
+		if !ok {
+			items = make(map[U]bool)
+			relevant[v.userId] = items
+			userIds = append(userIds, v.userId)
+		}
+		items[v.itemId] = true
+	}
+
+	if len(userIds) == 0 {
+		return float32(math.NaN())
+	}
+
+	var sum float64 = 0.0
+	for _, userId := range userIds {
+		items := relevant[userId]
+		recs := model.UserRecs(userId, metric.k)
+		sum += rankingScore(metric, recs, items)
+	}
+	return float32(sum / float64(len(userIds)))
+}
+
+func rankingScore[U Id](metric Metric, recs []Rec[U], relevant map[U]bool) float64 {
+	k := metric.k
+	ideal := min(k, len(relevant))
+
+	hits := 0
+	var dcg float64 = 0.0
+	var ap float64 = 0.0
+	for i, rec := range recs {
+		if relevant[rec.Id] {
+			hits++
+			dcg += 1.0 / math.Log2(float64(i+2))
+			ap += float64(hits) / float64(i+1)
+		}
+	}
+
+	switch metric.name {
+	case "precision":
+		return float64(hits) / float64(k)
+	case "recall":
+		return float64(hits) / float64(len(relevant))
+	case "ndcg":
+		var idcg float64 = 0.0
+		for i := range ideal {
+			idcg += 1.0 / math.Log2(float64(i+2))
+		}
+		return dcg / idcg
+	default:
+		return ap / float64(ideal)
+	}
+}
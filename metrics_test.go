@@ -0,0 +1,107 @@
+package disco_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ankane/disco-go"
+)
+
+func TestEvaluate(t *testing.T) {
+	baseline, err := disco.FitPopular(baselineData())
+	assertNil(t, err)
+
+	validSet := disco.NewDataset[int, string]()
+	validSet.Push(5, "C", 1.0)
+	validSet.Push(5, "E", 1.0)
+	validSet.Push(6, "B", -1.0)
+
+	assertInDelta(t, 1.4142, disco.Evaluate(baseline, validSet, disco.Rmse()), 0.0001)
+	assertInDelta(t, 0.5, disco.Evaluate(baseline, validSet, disco.PrecisionAt(2)), 0.0001)
+	assertInDelta(t, 0.5, disco.Evaluate(baseline, validSet, disco.RecallAt(2)), 0.0001)
+	assertInDelta(t, 0.3869, disco.Evaluate(baseline, validSet, disco.NdcgAt(2)), 0.0001)
+	assertInDelta(t, 0.25, disco.Evaluate(baseline, validSet, disco.MapAt(2)), 0.0001)
+	assertTrue(t, math.IsNaN(float64(disco.Evaluate(baseline, validSet, disco.Metric{}))))
+}
+
+func TestMetricString(t *testing.T) {
+	assertEqual(t, "rmse", disco.Rmse().String())
+	assertEqual(t, "ndcg@10", disco.NdcgAt(10).String())
+}
+
+func TestParseMetric(t *testing.T) {
+	for _, name := range []string{"rmse", "precision@5", "recall@10", "ndcg@10", "map@3"} {
+		metric, err := disco.ParseMetric(name)
+		assertNil(t, err)
+		assertEqual(t, name, metric.String())
+	}
+
+	for _, name := range []string{"", "mae", "ndcg", "ndcg@0", "ndcg@a", "rmse@5"} {
+		_, err := disco.ParseMetric(name)
+		assertError(t, err, "Invalid metric: "+name)
+	}
+}
+
+func TestKFold(t *testing.T) {
+	data := splitData()
+	folds := data.KFold(5)
+	assertEqual(t, 5, len(folds))
+	for _, fold := range folds {
+		assertEqual(t, 44, fold.TrainSet.Len())
+		assertEqual(t, 11, fold.ValidSet.Len())
+	}
+}
+
+func TestKFoldPerUser(t *testing.T) {
+	data := splitData()
+	folds := data.KFoldPerUser(3)
+	assertEqual(t, 3, len(folds))
+
+	total := 0
+	for _, fold := range folds {
+		baseline, err := disco.FitPopular(fold.TrainSet)
+		assertNil(t, err)
+		assertEqual(t, 10, len(baseline.UserIds()))
+		total += fold.ValidSet.Len()
+	}
+	// user with one rating is only in training sets
+	assertEqual(t, 54, total)
+}
+
+func TestKFoldTooFew(t *testing.T) {
+	data := splitData()
+	for _, k := range []int{-1, 0, 1} {
+		assertEqual(t, 0, len(data.KFold(k)))
+		assertEqual(t, 0, len(data.KFoldPerUser(k)))
+	}
+
+	_, err := disco.CrossValidateExplicit(data.KFold(0), disco.Rmse())
+	assertError(t, err, "No folds")
+}
+
+func TestKFoldTooMany(t *testing.T) {
+	data := splitData()
+	assertEqual(t, data.Len(), len(data.KFold(1000)))
+
+	_, err := disco.CrossValidateImplicit(data.KFoldPerUser(1000), disco.PrecisionAt(5))
+	assertError(t, err, "Empty validation set")
+}
+
+func TestCrossValidate(t *testing.T) {
+	data := splitData()
+
+	result, err := disco.CrossValidateExplicit(data.KFold(3), disco.Rmse(), disco.Factors(4))
+	assertNil(t, err)
+	assertEqual(t, 3, len(result.Scores))
+	assertTrue(t, result.StdDev >= 0)
+
+	result, err = disco.CrossValidateImplicit(data.KFoldPerUser(3), disco.PrecisionAt(5))
+	assertNil(t, err)
+	assertEqual(t, 3, len(result.Scores))
+	assertTrue(t, result.Mean >= 0 && result.Mean <= 1)
+}
+
+func TestCrossValidateInvalidMetric(t *testing.T) {
+	_, err := disco.CrossValidateExplicit(splitData().KFold(3), disco.PrecisionAt(0))
+	assertError(t, err, "Invalid metric")
+}
@@ -0,0 +1,192 @@
+package disco
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The MovieLens 100K dataset with metadata.
+type MovieLens struct {
+	// The ratings, with movie titles as item ids.
+	Ratings *Dataset[int, string]
+	// The movies by title.
+	Movies map[string]Movie
+	// The users by id.
+	Users map[int]MovieLensUser
+}
+
+// A movie in the MovieLens dataset.
+type Movie struct {
+	Id    int
+	Title string
+	// The zero value if unknown.
+	ReleaseDate time.Time
+	Genres      []string
+	ImdbUrl     string
+}
+
+// A user in the MovieLens dataset.
+type MovieLensUser struct {
+	Id         int
+	Age        int
+	Gender     string
+	Occupation string
+	ZipCode    string
+}
+
+var movieLensGenres = []string{
+	"unknown", "Action", "Adventure", "Animation", "Children's", "Comedy", "Crime", "Documentary", "Drama", "Fantasy",
+	"Film-Noir", "Horror", "Musical", "Mystery", "Romance", "Sci-Fi", "Thriller", "War", "Western",
+}
+
+// Loads the MovieLens 100K dataset with movie and user metadata.
+//
+// Movies with the same title share an item id, so only the first is included.
+func LoadMovieLensMetadata(options ...LoadOption) (*MovieLens, error) {
+	config := newLoadConfig(options)
+	fsys, cleanup, err := loadFS(config, func(root *os.Root) (fs.FS, func() error, error) {
+		return config.downloadMovieLens(root, "u.item", "u.data", "u.user")
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	movies, err := readMovieLensMovies(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := readMovieLensUsers(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make(map[string]string, len(movies))
+	moviesByTitle := make(map[string]Movie, len(movies))
+	for _, movie := range movies {
+		titles[strconv.Itoa(movie.Id)] = movie.Title
+		_, ok := moviesByTitle[movie.Title]
+		if !ok {
+			moviesByTitle[movie.Title] = movie
+		}
+	}
+
+	data := NewDataset[int, string]()
+	data.Grow(100000)
+	err = readRatings(fsys, "u.data", "\t", titles, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MovieLens{Ratings: data, Movies: moviesByTitle, Users: users}, nil
+}
+
+// Returns genres as item features, in order of movie id.
+func (m *MovieLens) ItemFeatures() *Features[string] {
+	movies := slices.SortedFunc(maps.Values(m.Movies), func(a Movie, b Movie) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+
+	features := NewFeatures[string]()
+	for _, movie := range movies {
+		for _, genre := range movie.Genres {
+			features.Push(movie.Title, "genre:"+genre, 1.0)
+		}
+	}
+	return features
+}
+
+// Returns age (by decade), gender, and occupation as user features, in order of user id.
+func (m *MovieLens) UserFeatures() *Features[int] {
+	features := NewFeatures[int]()
+	for _, id := range slices.Sorted(maps.Keys(m.Users)) {
+		user := m.Users[id]
+		features.Push(user.Id, fmt.Sprintf("age:%ds", user.Age/10*10), 1.0)
+		features.Push(user.Id, "gender:"+user.Gender, 1.0)
+		features.Push(user.Id, "occupation:"+user.Occupation, 1.0)
+	}
+	return features
+}
+
+func readMovieLensMovies(fsys fs.FS) ([]Movie, error) {
+	file, err := fsys.Open("u.item")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	movies := make([]Movie, 0, 1682)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		row := strings.Split(convertToUtf8(scanner.Text()), "|")
+		if len(row) < 5+len(movieLensGenres) {
+			return nil, fmt.Errorf("Invalid u.item line: %s", scanner.Text())
+		}
+
+		id, err := strconv.Atoi(row[0])
+		if err != nil {
+			return nil, err
+		}
+
+		var releaseDate time.Time
+		if row[2] != "" {
+			releaseDate, err = time.Parse("02-Jan-2006", row[2])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		genres := make([]string, 0)
+		for i, genre := range movieLensGenres {
+			if row[5+i] == "1" {
+				genres = append(genres, genre)
+			}
+		}
+
+		movies = append(movies, Movie{Id: id, Title: row[1], ReleaseDate: releaseDate, Genres: genres, ImdbUrl: row[4]})
+	}
+
+	return movies, scanner.Err()
+}
+
+func readMovieLensUsers(fsys fs.FS) (map[int]MovieLensUser, error) {
+	file, err := fsys.Open("u.user")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	users := make(map[int]MovieLensUser, 943)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		row := strings.Split(scanner.Text(), "|")
+		if len(row) < 5 {
+			return nil, fmt.Errorf("Invalid u.user line: %s", scanner.Text())
+		}
+
+		id, err := strconv.Atoi(row[0])
+		if err != nil {
+			return nil, err
+		}
+
+		age, err := strconv.Atoi(row[1])
+		if err != nil {
+			return nil, err
+		}
+
+		users[id] = MovieLensUser{Id: id, Age: age, Gender: row[2], Occupation: row[3], ZipCode: row[4]}
+	}
+
+	return users, scanner.Err()
+}
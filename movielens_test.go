@@ -0,0 +1,77 @@
+package disco_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ankane/disco-go"
+)
+
+func TestLoadMovieLensMetadata(t *testing.T) {
+	movieLens, err := disco.LoadMovieLensMetadata(disco.FromDir("testdata/ml-100k"))
+	assertNil(t, err)
+	assertEqual(t, 4, movieLens.Ratings.Len())
+	assertEqual(t, 3, len(movieLens.Movies))
+	assertEqual(t, 2, len(movieLens.Users))
+
+	movie := movieLens.Movies["Toy Story (1995)"]
+	assertEqual(t, 1, movie.Id)
+	assertTrue(t, movie.ReleaseDate.Equal(time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assertDeepEqual(t, []string{"Animation", "Children's", "Comedy"}, movie.Genres)
+	assertEqual(t, "http://us.imdb.com/M/title-exact?Toy%20Story%20(1995)", movie.ImdbUrl)
+
+	// same ids as ratings
+	for rating := range movieLens.Ratings.All() {
+		_, ok := movieLens.Movies[rating.ItemId]
+		assertTrue(t, ok)
+	}
+
+	user := movieLens.Users[2]
+	assertEqual(t, 53, user.Age)
+	assertEqual(t, "F", user.Gender)
+	assertEqual(t, "other", user.Occupation)
+	assertEqual(t, "94043", user.ZipCode)
+}
+
+func TestLoadMovieLensMetadataDownload(t *testing.T) {
+	client, requests := testClient(t, movieLensFiles(t))
+
+	movieLens, err := disco.LoadMovieLensMetadata(disco.CacheDir(t.TempDir()), disco.HTTPClient(client), disco.Logger(nil))
+	assertNil(t, err)
+	assertEqual(t, 4, movieLens.Ratings.Len())
+	assertEqual(t, 3, len(movieLens.Movies))
+	assertEqual(t, 2, len(movieLens.Users))
+	assertEqual(t, 3, *requests)
+}
+
+func TestMovieLensFeatures(t *testing.T) {
+	movieLens, err := disco.LoadMovieLensMetadata(disco.FromDir("testdata/ml-100k"))
+	assertNil(t, err)
+	assertEqual(t, 9, movieLens.ItemFeatures().Len())
+	assertEqual(t, 6, movieLens.UserFeatures().Len())
+
+	recommender, err := disco.FitExplicit(
+		movieLens.Ratings,
+		disco.ItemFeatures(movieLens.ItemFeatures()),
+		disco.UserFeatures(movieLens.UserFeatures()),
+		disco.Iterations(1),
+	)
+	assertNil(t, err)
+	assertEqual(t, 3, len(recommender.ItemIds()))
+
+	// reproducible with a seed
+	fit := func() []float32 {
+		recommender, err := disco.FitExplicit(
+			movieLens.Ratings,
+			disco.ItemFeatures(movieLens.ItemFeatures()),
+			disco.UserFeatures(movieLens.UserFeatures()),
+			disco.Seed(42),
+		)
+		assertNil(t, err)
+		return recommender.ItemFactors("Toy Story (1995)")
+	}
+	factors := fit()
+	for range 5 {
+		assertDeepEqual(t, factors, fit())
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math/rand/v2"
 	"slices"
 	"sort"
+	"time"
 )
 
 // An id.
@@ -13,6 +14,22 @@ type Id interface {
 	string | int | uint | int8 | int16 | int32 | int64 | uint8 | uint16 | uint32 | uint64
 }
 
+// A model that makes recommendations.
+type Model[T Id, U Id] interface {
+	// Returns recommendations for a user.
+	UserRecs(userId T, count int) []Rec[U]
+	// Returns recommendations for an item.
+	ItemRecs(itemId U, count int) []Rec[U]
+	// Returns similar users.
+	SimilarUsers(userId T, count int) []Rec[T]
+	// Returns the predicted score for a specific user and item.
+	Predict(userId T, itemId U) float32
+	// Returns user ids.
+	UserIds() []T
+	// Returns item ids.
+	ItemIds() []U
+}
+
 // A recommender.
 type Recommender[T Id, U Id] struct {
 	userMap     map[T]int
@@ -25,6 +42,9 @@ type Recommender[T Id, U Id] struct {
 	itemFactors *matrix
 	userNorms   []float32
 	itemNorms   []float32
+
+	itemFeatureMap     map[string]int
+	itemFeatureFactors *matrix
 }
 
 // A recommendation.
@@ -46,6 +66,7 @@ type FitInfo struct {
 type sparseRow struct {
 	index      int
 	confidence float32
+	preference float32
 }
 
 // Creates a recommender with explicit feedback.
@@ -64,21 +85,42 @@ func FitEvalExplicit[T Id, U Id](trainSet *Dataset[T, U], validSet *Dataset[T, U
 }
 
 func fit[T Id, U Id](trainSet *Dataset[T, U], validSet *Dataset[T, U], implicit bool, options ...Option) (*Recommender[T, U], error) {
-	config := &config{
-		factors:      8,
-		iterations:   20,
-		learningRate: 0.1,
-		alpha:        40.0,
-		seed:         rand.Uint64(),
-	}
-	for _, opt := range options {
-		opt(config)
-	}
+	config := newConfig(options)
 
 	if trainSet.Len() == 0 {
 		return nil, errors.New("No training data")
 	}
 
+	if config.duplicates != nil {
+		var err error
+		trainSet, err = trainSet.Deduplicate(*config.duplicates)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var userFeatures *Features[T]
+	if config.userFeatures != nil {
+		features, ok := config.userFeatures.(*Features[T])
+		if !ok {
+			return nil, errors.New("User features must have the same id type as users")
+		}
+		userFeatures = features
+	}
+
+	var itemFeatures *Features[U]
+	if config.itemFeatures != nil {
+		features, ok := config.itemFeatures.(*Features[U])
+		if !ok {
+			return nil, errors.New("Item features must have the same id type as items")
+		}
+		itemFeatures = features
+	}
+
+	if implicit && config.epsilon != nil && !(*config.epsilon > 0) {
+		return nil, errors.New("Epsilon must be positive")
+	}
+
 	userMap := make(map[T]int, 0)
 	itemMap := make(map[U]int, 0)
 	userIds := make([]T, 0)
@@ -98,6 +140,16 @@ func fit[T Id, U Id](trainSet *Dataset[T, U], validSet *Dataset[T, U], implicit
 	cui := [][]sparseRow{}
 	ciu := [][]sparseRow{}
 
+	// ages are relative to the most recent rating
+	var latest time.Time
+	if implicit && config.decay != nil {
+		for _, rating := range trainSet.data {
+			if rating.time.After(latest) {
+				latest = rating.time
+			}
+		}
+	}
+
 	for _, rating := range trainSet.data {
 		u, ok := userMap[rating.userId]
 		if !ok {
@@ -123,9 +175,26 @@ func fit[T Id, U Id](trainSet *Dataset[T, U], validSet *Dataset[T, U], implicit
 				ciu = append(ciu, []sparseRow{})
 			}
 
-			confidence := 1.0 + config.alpha*rating.value
-			cui[u] = append(cui[u], sparseRow{index: i, confidence: confidence})
-			ciu[i] = append(ciu[i], sparseRow{index: u, confidence: confidence})
+			value := rating.value
+			var preference float32 = 1.0
+			if value < 0 {
+				if !config.negativeFeedback {
+					return nil, errors.New("Negative values not supported for implicit feedback")
+				}
+				value = -value
+				preference = 0.0
+			}
+
+			if config.decay != nil && !rating.time.IsZero() {
+				value *= config.decay(latest.Sub(rating.time))
+			}
+
+			confidence := config.confidence(value, config.alpha)
+			if !(confidence >= 0) || math.IsInf(float64(confidence), 1) {
+				return nil, errors.New("Confidence must be finite and non-negative")
+			}
+			cui[u] = append(cui[u], sparseRow{index: i, confidence: confidence, preference: preference})
+			ciu[i] = append(ciu[i], sparseRow{index: u, confidence: confidence, preference: preference})
 		} else {
 			rowInds = append(rowInds, u)
 			colInds = append(colInds, i)
@@ -155,20 +224,39 @@ func fit[T Id, U Id](trainSet *Dataset[T, U], validSet *Dataset[T, U], implicit
 		endRange = 0.1
 	}
 
-	userFactors := createFactors(users, factors, rng, endRange)
-	itemFactors := createFactors(items, factors, rng, endRange)
-
 	recommender := &Recommender[T, U]{
-		userMap:     userMap,
-		itemMap:     itemMap,
-		userIds:     userIds,
-		itemIds:     itemIds,
-		rated:       rated,
-		globalMean:  globalMean,
-		userFactors: userFactors,
-		itemFactors: itemFactors,
+		userMap:    userMap,
+		itemMap:    itemMap,
+		userIds:    userIds,
+		itemIds:    itemIds,
+		rated:      rated,
+		globalMean: globalMean,
+	}
+
+	if userFeatures != nil || itemFeatures != nil {
+		var samples []sample
+		if implicit {
+			for u, rowVec := range cui {
+				for _, row := range rowVec {
+					samples = append(samples, sample{user: u, item: row.index, preference: row.preference, weight: row.confidence})
+				}
+			}
+		} else {
+			for j := range values {
+				samples = append(samples, sample{user: rowInds[j], item: colInds[j], preference: values[j], weight: 1.0})
+			}
+		}
+
+		fitHybrid(recommender, samples, validSet, implicit, userFeatures, itemFeatures, config, rng, endRange)
+		recommender.computeNorms()
+		return recommender, nil
 	}
 
+	userFactors := createFactors(users, factors, rng, endRange)
+	itemFactors := createFactors(items, factors, rng, endRange)
+	recommender.userFactors = userFactors
+	recommender.itemFactors = itemFactors
+
 	if implicit {
 		// conjugate gradient method
 		// https://www.benfrederickson.com/fast-implicit-matrix-factorization/
@@ -307,9 +395,16 @@ func fit[T Id, U Id](trainSet *Dataset[T, U], validSet *Dataset[T, U], implicit
 		}
 	}
 
+	recommender.computeNorms()
 	return recommender, nil
 }
 
+// computes norms up front so methods are safe for concurrent use
+func (r *Recommender[T, U]) computeNorms() {
+	r.userNorms = r.userFactors.Norms()
+	r.itemNorms = r.itemFactors.Norms()
+}
+
 // Returns recommendations for a user.
 func (r *Recommender[T, U]) UserRecs(userId T, count int) []Rec[U] {
 	u, ok := r.userMap[userId]
@@ -327,7 +422,7 @@ func (r *Recommender[T, U]) UserRecs(userId T, count int) []Rec[U] {
 		return predictions[j].Score > predictions[k].Score
 	})
 
-	recs := make([]Rec[U], 0, count)
+	recs := make([]Rec[U], 0, min(count, len(predictions)))
 	for _, prediction := range predictions {
 		_, ok := rated[prediction.Id]
 		if !ok {
@@ -342,17 +437,11 @@ func (r *Recommender[T, U]) UserRecs(userId T, count int) []Rec[U] {
 
 // Returns recommendations for an item.
 func (r *Recommender[T, U]) ItemRecs(itemId U, count int) []Rec[U] {
-	if r.itemNorms == nil {
-		r.itemNorms = r.itemFactors.Norms()
-	}
 	return similar(r.itemMap, r.itemIds, r.itemFactors, r.itemNorms, itemId, count)
 }
 
 // Returns similar users.
 func (r *Recommender[T, U]) SimilarUsers(userId T, count int) []Rec[T] {
-	if r.userNorms == nil {
-		r.userNorms = r.userFactors.Norms()
-	}
 	return similar(r.userMap, r.userIds, r.userFactors, r.userNorms, userId, count)
 }
 
@@ -442,8 +531,7 @@ func leastSquaresCg(cui [][]sparseRow, x *matrix, y *matrix, regularization floa
 		neg(r)
 		for _, row := range rowVec {
 			i := row.index
-			var confidence float32 = row.confidence
-			scaledAdd(r, confidence-(confidence-1.0)*dot(y.Row(i), xi), y.Row(i))
+			scaledAdd(r, row.confidence*row.preference-(row.confidence-1.0)*dot(y.Row(i), xi), y.Row(i))
 		}
 
 		p := make([]float32, factors)
@@ -451,12 +539,15 @@ func leastSquaresCg(cui [][]sparseRow, x *matrix, y *matrix, regularization floa
 		rsold := dot(r, r)
 
 		for range cgSteps {
+			if rsold < 1e-20 {
+				break
+			}
+
 			// calculate Ap = YtCuYp - without actually calculating YtCuY
 			ap := yty.Dot(p)
 			for _, row := range rowVec {
 				i := row.index
-				var confidence float32 = row.confidence
-				scaledAdd(ap, (confidence-1.0)*dot(y.Row(i), p), y.Row(i))
+				scaledAdd(ap, (row.confidence-1.0)*dot(y.Row(i), p), y.Row(i))
 			}
 
 			// standard CG update
@@ -465,10 +556,6 @@ func leastSquaresCg(cui [][]sparseRow, x *matrix, y *matrix, regularization floa
 			scaledAdd(r, -alpha, ap)
 			rsnew := dot(r, r)
 
-			if rsnew < 1e-20 {
-				break
-			}
-
 			rs := rsnew / rsold
 			for i := range p {
 				p[i] = r[i] + rs*p[i]
@@ -507,7 +594,7 @@ func similar[T Id](idMap map[T]int, ids []T, factors *matrix, norms []float32, i
 		return predictions[j].Score > predictions[k].Score
 	})
 
-	recs := make([]Rec[T], 0, count)
+	recs := make([]Rec[T], 0, min(count, len(predictions)))
 	for _, prediction := range predictions {
 		if prediction.Id != i {
 			recs = append(recs, Rec[T]{Id: ids[prediction.Id], Score: prediction.Score})
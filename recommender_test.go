@@ -1,8 +1,10 @@
 package disco_test
 
 import (
+	"math"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/ankane/disco-go"
 )
@@ -189,6 +191,163 @@ func TestCallback(t *testing.T) {
 	assertEqual(t, 20, iterations)
 }
 
+func TestModel(t *testing.T) {
+	data := disco.NewDataset[int, string]()
+	data.Push(1, "A", 1.0)
+	data.Push(1, "B", 1.0)
+	data.Push(2, "B", 1.0)
+
+	recommender, err := disco.FitExplicit(data)
+	assertNil(t, err)
+
+	baseline, err := disco.FitPopular(data)
+	assertNil(t, err)
+
+	models := []disco.Model[int, string]{recommender, baseline}
+	for _, model := range models {
+		assertDeepEqual(t, []int{1, 2}, model.UserIds())
+		assertDeepEqual(t, []string{"A", "B"}, model.ItemIds())
+		assertDeepEqual(t, []string{"A"}, getIds(model.UserRecs(2, 5)))
+	}
+}
+
+func TestDecay(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := disco.NewDataset[int, string]()
+	data.PushAt(1, "A", 1.0, now.Add(-48*time.Hour))
+	data.PushAt(1, "B", 1.0, now)
+	data.Push(2, "B", 1.0)
+
+	ages := []time.Duration{}
+	decay := func(age time.Duration) float32 {
+		ages = append(ages, age)
+		return 0.5
+	}
+	_, err := disco.FitImplicit(data, disco.Decay(decay))
+	assertNil(t, err)
+
+	assertDeepEqual(t, []time.Duration{48 * time.Hour, 0}, ages)
+
+	// recent feedback has more confidence
+	data = disco.NewDataset[int, string]()
+	for u := range 10 {
+		data.PushAt(u, "A", 1.0, now.Add(-240*time.Hour))
+		data.PushAt(u, "B", 1.0, now)
+	}
+	options := []disco.Option{disco.Factors(1), disco.Regularization(100), disco.Seed(42)}
+
+	recommender, err := disco.FitImplicit(data, options...)
+	assertNil(t, err)
+	assertInDelta(t, recommender.Predict(0, "A"), recommender.Predict(0, "B"), 0.001)
+
+	recommender, err = disco.FitImplicit(data, append(options, disco.HalfLife(24*time.Hour))...)
+	assertNil(t, err)
+	assertTrue(t, recommender.Predict(0, "B") > recommender.Predict(0, "A")+0.1)
+}
+
+func TestConfidence(t *testing.T) {
+	data := disco.NewDataset[int, string]()
+	data.Push(1, "A", 1.0)
+	data.Push(1, "B", 3.0)
+	data.Push(2, "B", 1.0)
+
+	values := []float32{}
+	confidence := func(value float32) float32 {
+		values = append(values, value)
+		return 1.0 + value
+	}
+	_, err := disco.FitImplicit(data, disco.Confidence(confidence))
+	assertNil(t, err)
+	assertDeepEqual(t, []float32{1.0, 3.0, 1.0}, values)
+
+	// logarithmic confidence dampens large values
+	data = disco.NewDataset[int, string]()
+	for u := range 10 {
+		data.Push(u, "A", 1.0)
+		data.Push(u, "B", 100.0)
+	}
+	options := []disco.Option{disco.Factors(1), disco.Regularization(100), disco.Seed(42)}
+
+	linear, err := disco.FitImplicit(data, append(options, disco.LinearConfidence())...)
+	assertNil(t, err)
+	linearGap := linear.Predict(0, "B") - linear.Predict(0, "A")
+	assertTrue(t, linearGap > 0)
+
+	log, err := disco.FitImplicit(data, append(options, disco.LogConfidence(1))...)
+	assertNil(t, err)
+	logGap := log.Predict(0, "B") - log.Predict(0, "A")
+	assertTrue(t, logGap > 0)
+	assertTrue(t, logGap < linearGap-0.1)
+}
+
+func TestInvalidConfidence(t *testing.T) {
+	data := disco.NewDataset[int, string]()
+	data.Push(1, "A", 1.0)
+	data.Push(2, "A", 0.0)
+
+	_, err := disco.FitImplicit(data, disco.LogConfidence(0))
+	assertError(t, err, "Epsilon must be positive")
+
+	_, err = disco.FitImplicit(data, disco.Alpha(-2))
+	assertError(t, err, "Confidence must be finite and non-negative")
+
+	_, err = disco.FitImplicit(data, disco.Confidence(func(value float32) float32 {
+		return float32(math.Inf(1))
+	}))
+	assertError(t, err, "Confidence must be finite and non-negative")
+
+	_, err = disco.FitImplicit(data, disco.Confidence(func(value float32) float32 {
+		return float32(math.NaN())
+	}))
+	assertError(t, err, "Confidence must be finite and non-negative")
+}
+
+func TestNegativeImplicit(t *testing.T) {
+	data := disco.NewDataset[int, string]()
+	data.Push(1, "A", 1.0)
+	data.Push(1, "B", -1.0)
+
+	_, err := disco.FitImplicit(data)
+	assertError(t, err, "Negative values not supported for implicit feedback")
+}
+
+func TestNegativeFeedback(t *testing.T) {
+	data := disco.NewDataset[int, string]()
+	for u := range 20 {
+		data.Push(u, "A", 1.0)
+		data.Push(u, "B", 1.0)
+		data.Push(u, "C", 1.0)
+		if u%2 == 0 {
+			data.Push(u, "D", 1.0)
+		}
+	}
+	data.Push(20, "A", 1.0)
+	data.Push(20, "B", 1.0)
+	data.Push(20, "C", -5.0)
+
+	recommender, err := disco.FitImplicit(data, disco.NegativeFeedback(), disco.Seed(42))
+	assertNil(t, err)
+
+	assertDeepEqual(t, []string{"D"}, getIds(recommender.UserRecs(20, 5)))
+	assertInDelta(t, 0.0, recommender.Predict(20, "C"), 0.05)
+
+	// negative confidence is not treated as negative feedback
+	_, err = disco.FitImplicit(data, disco.NegativeFeedback(), disco.Alpha(-1))
+	assertError(t, err, "Confidence must be finite and non-negative")
+}
+
+func TestLargeCount(t *testing.T) {
+	data := disco.NewDataset[int, string]()
+	data.Push(1, "A", 1.0)
+	data.Push(2, "B", 1.0)
+
+	recommender, err := disco.FitImplicit(data)
+	assertNil(t, err)
+	assertEqual(t, 1, len(recommender.UserRecs(1, math.MaxInt)))
+	assertEqual(t, 1, len(recommender.ItemRecs("A", math.MaxInt)))
+	assertEqual(t, 1, len(recommender.SimilarUsers(1, math.MaxInt)))
+}
+
 func TestNoTrainingData(t *testing.T) {
 	data := disco.NewDataset[int, string]()
 	_, err := disco.FitExplicit(data)
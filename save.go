@@ -0,0 +1,118 @@
+package disco
+
+import (
+	"encoding/gob"
+	"errors"
+	"io"
+	"maps"
+	"slices"
+)
+
+const saveVersion = 1
+
+type savedRecommender[T Id, U Id] struct {
+	Version            int
+	UserIds            []T
+	ItemIds            []U
+	Rated              [][]int
+	GlobalMean         float32
+	Factors            int
+	UserFactors        []float32
+	ItemFactors        []float32
+	ItemFeatures       []string
+	ItemFeatureIndexes []int
+	ItemFeatureFactors []float32
+}
+
+// Saves the recommender.
+func (r *Recommender[T, U]) Save(w io.Writer) error {
+	rated := make([][]int, len(r.rated))
+	for u, items := range r.rated {
+		rated[u] = slices.Sorted(maps.Keys(items))
+	}
+
+	saved := savedRecommender[T, U]{
+		Version:     saveVersion,
+		UserIds:     r.userIds,
+		ItemIds:     r.itemIds,
+		Rated:       rated,
+		GlobalMean:  r.globalMean,
+		Factors:     r.userFactors.cols,
+		UserFactors: r.userFactors.data,
+		ItemFactors: r.itemFactors.data,
+	}
+
+	if r.itemFeatureFactors != nil {
+		saved.ItemFeatures = slices.Sorted(maps.Keys(r.itemFeatureMap))
+		saved.ItemFeatureIndexes = make([]int, len(saved.ItemFeatures))
+		for k, name := range saved.ItemFeatures {
+			saved.ItemFeatureIndexes[k] = r.itemFeatureMap[name]
+		}
+		saved.ItemFeatureFactors = r.itemFeatureFactors.data
+	}
+
+	return gob.NewEncoder(w).Encode(saved)
+}
+
+// Loads a recommender saved with Save.
+func Load[T Id, U Id](r io.Reader) (*Recommender[T, U], error) {
+	var saved savedRecommender[T, U]
+	err := gob.NewDecoder(r).Decode(&saved)
+	if err != nil {
+		return nil, err
+	}
+
+	if saved.Version != saveVersion {
+		return nil, errors.New("Unsupported model version")
+	}
+
+	users := len(saved.UserIds)
+	items := len(saved.ItemIds)
+	factors := saved.Factors
+	if factors <= 0 || len(saved.UserFactors) != users*factors || len(saved.ItemFactors) != items*factors || len(saved.Rated) > users || len(saved.ItemFeatureFactors)%factors != 0 || len(saved.ItemFeatureIndexes) != len(saved.ItemFeatures) {
+		return nil, errors.New("Invalid model")
+	}
+
+	recommender := &Recommender[T, U]{
+		userMap:     make(map[T]int, users),
+		itemMap:     make(map[U]int, items),
+		userIds:     append([]T{}, saved.UserIds...),
+		itemIds:     append([]U{}, saved.ItemIds...),
+		rated:       make([]map[int]bool, users),
+		globalMean:  saved.GlobalMean,
+		userFactors: &matrix{rows: users, cols: factors, data: saved.UserFactors},
+		itemFactors: &matrix{rows: items, cols: factors, data: saved.ItemFactors},
+	}
+
+	for u, userId := range saved.UserIds {
+		recommender.userMap[userId] = u
+		recommender.rated[u] = make(map[int]bool)
+	}
+	for i, itemId := range saved.ItemIds {
+		recommender.itemMap[itemId] = i
+	}
+	for u, rated := range saved.Rated {
+		for _, i := range rated {
+			if i < 0 || i >= items {
+				return nil, errors.New("Invalid model")
+			}
+			recommender.rated[u][i] = true
+		}
+	}
+
+	if saved.ItemFeatureFactors != nil {
+		rows := len(saved.ItemFeatureFactors) / factors
+		recommender.itemFeatureMap = make(map[string]int, len(saved.ItemFeatures))
+		for k, name := range saved.ItemFeatures {
+			j := saved.ItemFeatureIndexes[k]
+			if j < 0 || j >= rows {
+				return nil, errors.New("Invalid model")
+			}
+			recommender.itemFeatureMap[name] = j
+		}
+		recommender.itemFeatureFactors = &matrix{rows: rows, cols: factors, data: saved.ItemFeatureFactors}
+	}
+
+	recommender.computeNorms()
+	return recommender, nil
+}
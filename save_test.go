@@ -0,0 +1,63 @@
+package disco_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ankane/disco-go"
+)
+
+func TestSaveLoad(t *testing.T) {
+	data, features := hybridData()
+	recommender, err := disco.FitImplicit(data, disco.Seed(42))
+	assertNil(t, err)
+	err = recommender.FitItemFeatures(features)
+	assertNil(t, err)
+
+	var b bytes.Buffer
+	err = recommender.Save(&b)
+	assertNil(t, err)
+
+	loaded, err := disco.Load[int, string](&b)
+	assertNil(t, err)
+
+	assertDeepEqual(t, recommender.UserIds(), loaded.UserIds())
+	assertDeepEqual(t, recommender.ItemIds(), loaded.ItemIds())
+	assertDeepEqual(t, recommender.UserRecs(1, 5), loaded.UserRecs(1, 5))
+	assertDeepEqual(t, recommender.ItemRecs("comedy1", 5), loaded.ItemRecs("comedy1", 5))
+	assertDeepEqual(t, recommender.SimilarUsers(1, 5), loaded.SimilarUsers(1, 5))
+	assertEqual(t, recommender.Predict(1, "comedy1"), loaded.Predict(1, "comedy1"))
+	assertEqual(t, recommender.GlobalMean(), loaded.GlobalMean())
+
+	features2 := map[string]float32{"genre:comedy": 1.0}
+	assertDeepEqual(t, recommender.InferItem(features2), loaded.InferItem(features2))
+}
+
+func TestLoadWrongType(t *testing.T) {
+	recommender, err := disco.FitExplicit(baselineData())
+	assertNil(t, err)
+
+	var b bytes.Buffer
+	err = recommender.Save(&b)
+	assertNil(t, err)
+
+	_, err = disco.Load[string, string](&b)
+	assertTrue(t, err != nil)
+}
+
+func TestSaveLoadHybrid(t *testing.T) {
+	data, features := hybridData()
+	recommender, err := disco.FitImplicit(data, disco.ItemFeatures(features), disco.Seed(42))
+	assertNil(t, err)
+
+	var b bytes.Buffer
+	err = recommender.Save(&b)
+	assertNil(t, err)
+
+	loaded, err := disco.Load[int, string](&b)
+	assertNil(t, err)
+
+	assertDeepEqual(t, recommender.ItemRecs("new comedy", 5), loaded.ItemRecs("new comedy", 5))
+	features2 := map[string]float32{"genre:drama": 1.0}
+	assertDeepEqual(t, recommender.InferItem(features2), loaded.InferItem(features2))
+}
@@ -0,0 +1,262 @@
+package disco
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// A hyperparameter search.
+//
+// Parameters without values or a range use the value from the options.
+type Search struct {
+	// The numbers of factors.
+	Factors []int
+	// The numbers of iterations.
+	Iterations []int
+	// The regularization values.
+	Regularization []float32
+	// The learning rates.
+	LearningRate []float32
+	// The alpha values.
+	Alpha []float32
+	// The range of regularization values for random search.
+	RegularizationRange Range
+	// The range of learning rates for random search.
+	LearningRateRange Range
+	// The range of alpha values for random search.
+	AlphaRange Range
+	// The number of random candidates, or zero for grid search.
+	//
+	// Candidates are sampled with the seed from the options and are not repeated,
+	// so fewer are returned when there are not enough unique values.
+	Trials int
+	// The maximum number of candidates to train at once (defaults to the number of CPUs).
+	Workers int
+}
+
+// A range of values sampled on a log scale.
+//
+// Min must be positive and less than Max.
+type Range struct {
+	Min float32
+	Max float32
+}
+
+// Hyperparameters.
+type Params struct {
+	Factors        int
+	Iterations     int
+	Regularization float32
+	LearningRate   float32
+	Alpha          float32
+}
+
+// The result for a search candidate.
+type SearchResult struct {
+	Params Params
+	EvalResult
+}
+
+// Returns options for the hyperparameters.
+func (p Params) Options() []Option {
+	return []Option{
+		Factors(p.Factors),
+		Iterations(p.Iterations),
+		Regularization(p.Regularization),
+		LearningRate(p.LearningRate),
+		Alpha(p.Alpha),
+	}
+}
+
+// Returns a human-readable summary.
+func (p Params) String() string {
+	return fmt.Sprintf("factors=%d iterations=%d regularization=%g learningRate=%g alpha=%g", p.Factors, p.Iterations, p.Regularization, p.LearningRate, p.Alpha)
+}
+
+// Returns a human-readable summary.
+func (r SearchResult) String() string {
+	return fmt.Sprintf("%s mean=%g stddev=%g", r.Params, r.Mean, r.StdDev)
+}
+
+// Searches hyperparameters with explicit feedback.
+//
+// Returns results from best to worst and options for the best candidate.
+func SearchExplicit[T Id, U Id](search Search, folds []Fold[T, U], metric Metric, options ...Option) ([]SearchResult, []Option, error) {
+	return searchParams(search, folds, false, metric, options...)
+}
+
+// Searches hyperparameters with implicit feedback.
+//
+// Returns results from best to worst and options for the best candidate.
+func SearchImplicit[T Id, U Id](search Search, folds []Fold[T, U], metric Metric, options ...Option) ([]SearchResult, []Option, error) {
+	return searchParams(search, folds, true, metric, options...)
+}
+
+func searchParams[T Id, U Id](search Search, folds []Fold[T, U], implicit bool, metric Metric, options ...Option) ([]SearchResult, []Option, error) {
+	if !metric.valid() {
+		return nil, nil, errors.New("Invalid metric")
+	}
+
+	candidates, err := search.candidates(newConfig(options), implicit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workers := search.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	results := make([]SearchResult, len(candidates))
+	errs := make([]error, len(candidates))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, params := range candidates {
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+
+			candidateOptions := append(append([]Option{}, options...), params.Options()...)
+			result, err := crossValidate(folds, implicit, metric, false, candidateOptions...)
+			results[i] = SearchResult{Params: params, EvalResult: result}
+			errs[i] = err
+		})
+	}
+	wg.Wait()
+
+	err = errors.Join(errs...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a := results[i].Mean
+		b := results[j].Mean
+		if math.IsNaN(float64(b)) {
+			return !math.IsNaN(float64(a))
+		}
+		if metric.higherIsBetter() {
+			return a > b
+		}
+		return a < b
+	})
+
+	bestOptions := append(append([]Option{}, options...), results[0].Params.Options()...)
+	return results, bestOptions, nil
+}
+
+func (s Search) candidates(config *config, implicit bool) ([]Params, error) {
+	var regularization float32
+	if config.regularization != nil {
+		regularization = *config.regularization
+	} else if implicit {
+		regularization = 0.01
+	} else {
+		regularization = 0.1
+	}
+
+	factors := valuesOr(s.Factors, config.factors)
+	iterations := valuesOr(s.Iterations, config.iterations)
+	regularizations := valuesOr(s.Regularization, regularization)
+	learningRates := valuesOr(s.LearningRate, config.learningRate)
+	alphas := valuesOr(s.Alpha, config.alpha)
+
+	ranges := []struct {
+		r      Range
+		values []float32
+	}{
+		{s.RegularizationRange, s.Regularization},
+		{s.LearningRateRange, s.LearningRate},
+		{s.AlphaRange, s.Alpha},
+	}
+	hasRange := false
+	for _, v := range ranges {
+		if v.r == (Range{}) {
+			continue
+		}
+		if !(v.r.Min > 0 && v.r.Min < v.r.Max) || math.IsInf(float64(v.r.Max), 1) {
+			return nil, errors.New("Invalid range")
+		}
+		if len(v.values) > 0 {
+			return nil, errors.New("Parameters cannot have both values and a range")
+		}
+		hasRange = true
+	}
+
+	candidates := []Params{}
+	if s.Trials > 0 {
+		rng := rand.New(rand.NewPCG(config.seed, 0))
+		sample := func(r Range, values []float32) float32 {
+			if r == (Range{}) {
+				return values[rng.IntN(len(values))]
+			}
+			return logUniform(rng, r)
+		}
+
+		// stop once every combination is used
+		trials := s.Trials
+		if !hasRange {
+			trials = min(trials, countUnique(factors)*countUnique(iterations)*countUnique(regularizations)*countUnique(learningRates)*countUnique(alphas))
+		}
+
+		// give up on duplicates after enough attempts
+		seen := make(map[Params]bool)
+		for attempts := 0; len(candidates) < trials && attempts < trials*100; attempts++ {
+			params := Params{
+				Factors:        factors[rng.IntN(len(factors))],
+				Iterations:     iterations[rng.IntN(len(iterations))],
+				Regularization: sample(s.RegularizationRange, regularizations),
+				LearningRate:   sample(s.LearningRateRange, learningRates),
+				Alpha:          sample(s.AlphaRange, alphas),
+			}
+			if !seen[params] {
+				seen[params] = true
+				candidates = append(candidates, params)
+			}
+		}
+		return candidates, nil
+	}
+
+	if hasRange {
+		return nil, errors.New("Ranges require random search")
+	}
+
+	for _, f := range factors {
+		for _, i := range iterations {
+			for _, r := range regularizations {
+				for _, l := range learningRates {
+					for _, a := range alphas {
+						candidates = append(candidates, Params{Factors: f, Iterations: i, Regularization: r, LearningRate: l, Alpha: a})
+					}
+				}
+			}
+		}
+	}
+	return candidates, nil
+}
+
+func logUniform(rng *rand.Rand, r Range) float32 {
+	lo := math.Log(float64(r.Min))
+	hi := math.Log(float64(r.Max))
+	return float32(math.Exp(lo + rng.Float64()*(hi-lo)))
+}
+
+func countUnique[T comparable](values []T) int {
+	seen := make(map[T]bool)
+	for _, v := range values {
+		seen[v] = true
+	}
+	return len(seen)
+}
+
+func valuesOr[T any](values []T, value T) []T {
+	if len(values) == 0 {
+		return []T{value}
+	}
+	return values
+}
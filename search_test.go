@@ -0,0 +1,124 @@
+package disco_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/ankane/disco-go"
+)
+
+func TestSearchExplicit(t *testing.T) {
+	folds := splitData().KFold(3)
+	search := disco.Search{
+		Factors:        []int{2, 4},
+		Regularization: []float32{0.01, 0.1, 1.0},
+		Workers:        2,
+	}
+
+	results, options, err := disco.SearchExplicit(search, folds, disco.Rmse(), disco.Iterations(5))
+	assertNil(t, err)
+	assertEqual(t, 6, len(results))
+	assertEqual(t, 6, len(options))
+
+	for i, result := range results {
+		assertEqual(t, 5, result.Params.Iterations)
+		assertEqual(t, 0.1, result.Params.LearningRate)
+		assertEqual(t, 3, len(result.Scores))
+		if i > 0 {
+			assertTrue(t, results[i-1].Mean <= result.Mean)
+		}
+	}
+}
+
+func TestSearchImplicit(t *testing.T) {
+	folds := splitData().KFoldPerUser(2)
+	search := disco.Search{
+		Factors: []int{2, 4, 8},
+		Alpha:   []float32{1, 10, 40},
+		Trials:  4,
+	}
+
+	results, _, err := disco.SearchImplicit(search, folds, disco.PrecisionAt(3))
+	assertNil(t, err)
+	assertEqual(t, 4, len(results))
+
+	for i, result := range results {
+		assertEqual(t, 0.01, result.Params.Regularization)
+		if i > 0 {
+			assertTrue(t, results[i-1].Mean >= result.Mean)
+		}
+	}
+}
+
+func TestSearchRandom(t *testing.T) {
+	folds := splitData().KFoldPerUser(2)
+	search := disco.Search{
+		Factors:             []int{2, 4},
+		RegularizationRange: disco.Range{Min: 0.001, Max: 1},
+		AlphaRange:          disco.Range{Min: 1, Max: 100},
+		Trials:              5,
+	}
+
+	params := func() []string {
+		results, _, err := disco.SearchImplicit(search, folds, disco.PrecisionAt(3), disco.Seed(42))
+		assertNil(t, err)
+		values := []string{}
+		for _, result := range results {
+			assertTrue(t, result.Params.Regularization >= 0.001 && result.Params.Regularization <= 1)
+			assertTrue(t, result.Params.Alpha >= 1 && result.Params.Alpha <= 100)
+			values = append(values, result.Params.String())
+		}
+		slices.Sort(values)
+		return values
+	}
+	first := params()
+	assertEqual(t, 5, len(first))
+	assertDeepEqual(t, first, params())
+}
+
+func TestSearchRandomNoDuplicates(t *testing.T) {
+	folds := splitData().KFold(2)
+	search := disco.Search{
+		Factors: []int{2, 4, 4},
+		Trials:  10,
+	}
+
+	results, _, err := disco.SearchExplicit(search, folds, disco.Rmse(), disco.Iterations(1))
+	assertNil(t, err)
+	assertEqual(t, 2, len(results))
+	assertTrue(t, results[0].Params.Factors != results[1].Params.Factors)
+}
+
+func TestSearchRandomNarrowRange(t *testing.T) {
+	folds := splitData().KFold(2)
+	search := disco.Search{
+		AlphaRange: disco.Range{Min: 1, Max: math.Nextafter32(1, 2)},
+		Trials:     5,
+	}
+
+	results, _, err := disco.SearchImplicit(search, folds, disco.PrecisionAt(3), disco.Iterations(1))
+	assertNil(t, err)
+	assertTrue(t, len(results) <= 2)
+}
+
+func TestSearchInvalidRange(t *testing.T) {
+	folds := splitData().KFold(2)
+
+	_, _, err := disco.SearchExplicit(disco.Search{RegularizationRange: disco.Range{Min: 0.1, Max: 1}}, folds, disco.Rmse())
+	assertError(t, err, "Ranges require random search")
+
+	_, _, err = disco.SearchExplicit(disco.Search{AlphaRange: disco.Range{Min: 0, Max: 1}, Trials: 2}, folds, disco.Rmse())
+	assertError(t, err, "Invalid range")
+
+	_, _, err = disco.SearchExplicit(disco.Search{AlphaRange: disco.Range{Min: 1, Max: 1}, Trials: 2}, folds, disco.Rmse())
+	assertError(t, err, "Invalid range")
+
+	_, _, err = disco.SearchExplicit(disco.Search{Alpha: []float32{1}, AlphaRange: disco.Range{Min: 1, Max: 2}, Trials: 2}, folds, disco.Rmse())
+	assertError(t, err, "Parameters cannot have both values and a range")
+}
+
+func TestParamsString(t *testing.T) {
+	params := disco.Params{Factors: 8, Iterations: 20, Regularization: 0.1, LearningRate: 0.1, Alpha: 40}
+	assertEqual(t, "factors=8 iterations=20 regularization=0.1 learningRate=0.1 alpha=40", params.String())
+}
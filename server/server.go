@@ -0,0 +1,427 @@
+// Package server serves recommendations as JSON over HTTP.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ankane/disco-go"
+)
+
+// A recommendation server.
+//
+// Endpoints:
+//
+//	GET  /users/{id}/recs     recommendations for a user
+//	GET  /users/{id}/similar  similar users
+//	GET  /items/{id}/recs     recommendations for an item
+//	GET  /predict             predicted score for user_id and item_id
+//	GET  /health              health check
+//	GET  /model               model metadata
+//	POST /reload              reloads the model file
+//
+// Recommendation endpoints take count, exclude, and include parameters.
+// Pass exclude and include multiple times for multiple ids.
+type Server[T disco.Id, U disco.Id] struct {
+	mu       sync.RWMutex
+	model    *servedModel[T, U]
+	path     string
+	modTime  time.Time
+	loadedAt time.Time
+	mux      *http.ServeMux
+}
+
+// a model with id sets to check if users and items exist
+type servedModel[T disco.Id, U disco.Id] struct {
+	disco.Model[T, U]
+	users map[T]bool
+	items map[U]bool
+}
+
+// A recommendation.
+type Rec[T disco.Id] struct {
+	Id    T       `json:"id"`
+	Score float32 `json:"score"`
+}
+
+// Model metadata.
+type ModelInfo struct {
+	Users    int       `json:"users"`
+	Items    int       `json:"items"`
+	Factors  int       `json:"factors"`
+	Path     string    `json:"path,omitempty"`
+	LoadedAt time.Time `json:"loaded_at"`
+}
+
+var (
+	errNoFile       = errors.New("No model file")
+	errInvalidCount = errors.New("Invalid count")
+	errInvalidId    = errors.New("Invalid id")
+)
+
+// Creates a server for a model.
+//
+// The model must not be modified while the server is running.
+func New[T disco.Id, U disco.Id](model disco.Model[T, U]) *Server[T, U] {
+	s := &Server[T, U]{}
+	s.SetModel(model)
+	s.routes()
+	return s
+}
+
+// Creates a server for a model file saved with Save.
+func Open[T disco.Id, U disco.Id](path string) (*Server[T, U], error) {
+	s := &Server[T, U]{path: path}
+	err := s.Reload()
+	if err != nil {
+		return nil, err
+	}
+	s.routes()
+	return s, nil
+}
+
+// Replaces the model.
+func (s *Server[T, U]) SetModel(model disco.Model[T, U]) {
+	s.setModel(model, time.Time{})
+}
+
+func (s *Server[T, U]) setModel(model disco.Model[T, U], modTime time.Time) {
+	served := &servedModel[T, U]{
+		Model: model,
+		users: idSet(model.UserIds()),
+		items: idSet(model.ItemIds()),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.model = served
+	s.modTime = modTime
+	s.loadedAt = time.Now()
+}
+
+// Reloads the model file.
+//
+// The current model is kept if the file cannot be loaded.
+func (s *Server[T, U]) Reload() error {
+	if s.path == "" {
+		return errNoFile
+	}
+
+	f, err := os.Open(s.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	model, err := disco.Load[T, U](f)
+	if err != nil {
+		return err
+	}
+
+	s.setModel(model, info.ModTime())
+	return nil
+}
+
+// Reloads the model file when it changes until the context is canceled.
+//
+// Errors are logged and the current model is kept.
+func (s *Server[T, U]) Watch(ctx context.Context, interval time.Duration) error {
+	if s.path == "" {
+		return errNoFile
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			info, err := os.Stat(s.path)
+			if err != nil {
+				slog.Error("Model file unavailable", "path", s.path, "error", err)
+				continue
+			}
+
+			s.mu.RLock()
+			changed := !info.ModTime().Equal(s.modTime)
+			s.mu.RUnlock()
+
+			if changed {
+				err := s.Reload()
+				if err != nil {
+					slog.Error("Model reload failed", "path", s.path, "error", err)
+				} else {
+					slog.Info("Model reloaded", "path", s.path)
+				}
+			}
+		}
+	}
+}
+
+// Handles an HTTP request.
+func (s *Server[T, U]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server[T, U]) currentModel() *servedModel[T, U] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model
+}
+
+func (s *Server[T, U]) routes() {
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("GET /users/{id}/recs", s.userRecs)
+	s.mux.HandleFunc("GET /users/{id}/similar", s.similarUsers)
+	s.mux.HandleFunc("GET /items/{id}/recs", s.itemRecs)
+	s.mux.HandleFunc("GET /predict", s.predict)
+	s.mux.HandleFunc("GET /health", s.health)
+	s.mux.HandleFunc("GET /model", s.modelInfo)
+	s.mux.HandleFunc("POST /reload", s.reload)
+}
+
+func (s *Server[T, U]) userRecs(w http.ResponseWriter, r *http.Request) {
+	model := s.currentModel()
+
+	userId, err := disco.ParseId[T](r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid user id")
+		return
+	}
+	if !model.users[userId] {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	f, err := parseFilter[U](r, len(model.ItemIds()))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	recs := model.UserRecs(userId, f.limit())
+	writeJSON(w, http.StatusOK, recsResponse[U]{Recs: f.apply(recs)})
+}
+
+func (s *Server[T, U]) itemRecs(w http.ResponseWriter, r *http.Request) {
+	model := s.currentModel()
+
+	itemId, err := disco.ParseId[U](r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid item id")
+		return
+	}
+	if !model.items[itemId] {
+		writeError(w, http.StatusNotFound, "Item not found")
+		return
+	}
+
+	f, err := parseFilter[U](r, len(model.ItemIds()))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	recs := model.ItemRecs(itemId, f.limit())
+	writeJSON(w, http.StatusOK, recsResponse[U]{Recs: f.apply(recs)})
+}
+
+func (s *Server[T, U]) similarUsers(w http.ResponseWriter, r *http.Request) {
+	model := s.currentModel()
+
+	userId, err := disco.ParseId[T](r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid user id")
+		return
+	}
+	if !model.users[userId] {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	f, err := parseFilter[T](r, len(model.UserIds()))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	recs := model.SimilarUsers(userId, f.limit())
+	writeJSON(w, http.StatusOK, recsResponse[T]{Recs: f.apply(recs)})
+}
+
+func (s *Server[T, U]) predict(w http.ResponseWriter, r *http.Request) {
+	model := s.currentModel()
+
+	userId, err := disco.ParseId[T](r.URL.Query().Get("user_id"))
+	if err != nil || !r.URL.Query().Has("user_id") {
+		writeError(w, http.StatusBadRequest, "Invalid user id")
+		return
+	}
+
+	itemId, err := disco.ParseId[U](r.URL.Query().Get("item_id"))
+	if err != nil || !r.URL.Query().Has("item_id") {
+		writeError(w, http.StatusBadRequest, "Invalid item id")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, predictResponse[T, U]{UserId: userId, ItemId: itemId, Score: model.Predict(userId, itemId)})
+}
+
+func (s *Server[T, U]) health(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, statusResponse{Status: "ok"})
+}
+
+func (s *Server[T, U]) modelInfo(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	model := s.model
+	info := ModelInfo{
+		Users:    len(model.users),
+		Items:    len(model.items),
+		Path:     s.path,
+		LoadedAt: s.loadedAt,
+	}
+	s.mu.RUnlock()
+
+	// baselines do not have factors
+	factors, ok := model.Model.(interface{ ItemFactors(itemId U) []float32 })
+	if ids := model.ItemIds(); ok && len(ids) > 0 {
+		info.Factors = len(factors.ItemFactors(ids[0]))
+	}
+
+	writeJSON(w, http.StatusOK, info)
+}
+
+func (s *Server[T, U]) reload(w http.ResponseWriter, r *http.Request) {
+	err := s.Reload()
+	if err == errNoFile {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, statusResponse{Status: "ok"})
+}
+
+type recsResponse[T disco.Id] struct {
+	Recs []Rec[T] `json:"recs"`
+}
+
+type predictResponse[T disco.Id, U disco.Id] struct {
+	UserId T       `json:"user_id"`
+	ItemId U       `json:"item_id"`
+	Score  float32 `json:"score"`
+}
+
+type statusResponse struct {
+	Status string `json:"status"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+// count, exclude, and include parameters
+type filter[T disco.Id] struct {
+	count   int
+	total   int
+	exclude map[T]bool
+	include map[T]bool
+}
+
+const defaultCount = 10
+
+func parseFilter[T disco.Id](r *http.Request, total int) (*filter[T], error) {
+	query := r.URL.Query()
+	f := &filter[T]{count: defaultCount, total: total}
+
+	if query.Has("count") {
+		count, err := strconv.Atoi(query.Get("count"))
+		if err != nil || count < 1 {
+			return nil, errInvalidCount
+		}
+		// limit allocations
+		f.count = min(count, total)
+	}
+
+	var err error
+	f.exclude, err = parseIds[T](query["exclude"])
+	if err != nil {
+		return nil, err
+	}
+	f.include, err = parseIds[T](query["include"])
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func idSet[T disco.Id](ids []T) map[T]bool {
+	set := make(map[T]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func parseIds[T disco.Id](values []string) (map[T]bool, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	ids := make(map[T]bool, len(values))
+	for _, v := range values {
+		id, err := disco.ParseId[T](v)
+		if err != nil {
+			return nil, errInvalidId
+		}
+		ids[id] = true
+	}
+	return ids, nil
+}
+
+// fetches all recommendations when filtering
+func (f *filter[T]) limit() int {
+	if f.exclude != nil || f.include != nil {
+		return f.total
+	}
+	return f.count
+}
+
+func (f *filter[T]) apply(recs []disco.Rec[T]) []Rec[T] {
+	res := make([]Rec[T], 0, min(len(recs), f.count))
+	for _, rec := range recs {
+		if f.exclude[rec.Id] || (f.include != nil && !f.include[rec.Id]) {
+			continue
+		}
+		res = append(res, Rec[T]{Id: rec.Id, Score: rec.Score})
+		if len(res) == f.count {
+			break
+		}
+	}
+	return res
+}
@@ -0,0 +1,299 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ankane/disco-go"
+	"github.com/ankane/disco-go/server"
+)
+
+func assertEqual[T comparable](t *testing.T, exp T, act T) {
+	if act != exp {
+		t.Errorf("Failed")
+	}
+}
+
+func assertDeepEqual[T any](t *testing.T, exp T, act T) {
+	if !reflect.DeepEqual(act, exp) {
+		t.Errorf("Failed")
+	}
+}
+
+func assertNil[T any](t *testing.T, act T) {
+	if !reflect.DeepEqual(act, nil) {
+		t.Errorf("Failed")
+	}
+}
+
+func fitModel(t *testing.T, users int) *disco.Recommender[int, string] {
+	data := disco.NewDataset[int, string]()
+	for u := range users {
+		for i := range 10 {
+			if (u+i)%3 != 0 {
+				data.Push(u, fmt.Sprintf("item%d", i), 1.0)
+			}
+		}
+	}
+	recommender, err := disco.FitImplicit(data, disco.Seed(42))
+	assertNil(t, err)
+	return recommender
+}
+
+func saveModel(t *testing.T, recommender *disco.Recommender[int, string], path string) {
+	f, err := os.Create(path)
+	assertNil(t, err)
+	defer f.Close()
+	assertNil(t, recommender.Save(f))
+}
+
+func get(t *testing.T, handler http.Handler, method string, target string) (int, map[string]any) {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+	assertEqual(t, "application/json", w.Header().Get("Content-Type"))
+
+	var body map[string]any
+	assertNil(t, json.NewDecoder(w.Body).Decode(&body))
+	return w.Code, body
+}
+
+func recIds(body map[string]any) []string {
+	ids := []string{}
+	for _, rec := range body["recs"].([]any) {
+		ids = append(ids, fmt.Sprint(rec.(map[string]any)["id"]))
+	}
+	return ids
+}
+
+func TestUserRecs(t *testing.T) {
+	model := fitModel(t, 20)
+	s := server.New(model)
+
+	status, body := get(t, s, "GET", "/users/1/recs?count=2")
+	assertEqual(t, http.StatusOK, status)
+	expected := []string{}
+	for _, rec := range model.UserRecs(1, 2) {
+		expected = append(expected, rec.Id)
+	}
+	assertDeepEqual(t, expected, recIds(body))
+
+	_, body = get(t, s, "GET", "/users/1/recs?exclude="+expected[0])
+	assertDeepEqual(t, expected[1:], recIds(body)[:1])
+
+	_, body = get(t, s, "GET", "/users/1/recs?include=item2&include=item9")
+	assertEqual(t, 1, len(recIds(body)))
+}
+
+func TestItemRecs(t *testing.T) {
+	model := fitModel(t, 20)
+	s := server.New(model)
+
+	status, body := get(t, s, "GET", "/items/item1/recs")
+	assertEqual(t, http.StatusOK, status)
+	assertEqual(t, 9, len(recIds(body)))
+
+	_, body = get(t, s, "GET", "/items/item1/recs?count=3&exclude=item2")
+	assertEqual(t, 3, len(recIds(body)))
+	for _, id := range recIds(body) {
+		assertEqual(t, false, id == "item2")
+	}
+}
+
+func TestLargeCount(t *testing.T) {
+	s := server.New(fitModel(t, 20))
+
+	status, body := get(t, s, "GET", "/users/1/recs?count=9223372036854775807")
+	assertEqual(t, http.StatusOK, status)
+	assertEqual(t, 3, len(recIds(body)))
+
+	status, body = get(t, s, "GET", "/users/1/similar?count=9223372036854775807")
+	assertEqual(t, http.StatusOK, status)
+	assertEqual(t, 19, len(recIds(body)))
+}
+
+func TestBaseline(t *testing.T) {
+	data := disco.NewDataset[int, string]()
+	data.Push(1, "A", 1.0)
+	data.Push(1, "B", 1.0)
+	data.Push(2, "B", 1.0)
+	baseline, err := disco.FitPopular(data)
+	assertNil(t, err)
+	s := server.New(baseline)
+
+	status, body := get(t, s, "GET", "/users/2/recs")
+	assertEqual(t, http.StatusOK, status)
+	assertDeepEqual(t, []string{"A"}, recIds(body))
+
+	status, _ = get(t, s, "GET", "/users/3/recs")
+	assertEqual(t, http.StatusNotFound, status)
+
+	status, body = get(t, s, "GET", "/model")
+	assertEqual(t, http.StatusOK, status)
+	assertEqual(t, float64(2), body["items"].(float64))
+	assertEqual(t, float64(0), body["factors"].(float64))
+}
+
+func TestSimilarUsers(t *testing.T) {
+	s := server.New(fitModel(t, 20))
+
+	status, body := get(t, s, "GET", "/users/1/similar?count=5")
+	assertEqual(t, http.StatusOK, status)
+	assertEqual(t, 5, len(recIds(body)))
+}
+
+func TestPredict(t *testing.T) {
+	model := fitModel(t, 20)
+	s := server.New(model)
+
+	status, body := get(t, s, "GET", "/predict?user_id=1&item_id=item2")
+	assertEqual(t, http.StatusOK, status)
+	assertEqual(t, float64(1), body["user_id"].(float64))
+	assertEqual(t, "item2", body["item_id"].(string))
+	assertEqual(t, model.Predict(1, "item2"), float32(body["score"].(float64)))
+
+	status, body = get(t, s, "GET", "/predict?item_id=item2")
+	assertEqual(t, http.StatusBadRequest, status)
+	assertEqual(t, "Invalid user id", body["error"].(string))
+}
+
+func TestErrors(t *testing.T) {
+	s := server.New(fitModel(t, 20))
+
+	status, body := get(t, s, "GET", "/users/100/recs")
+	assertEqual(t, http.StatusNotFound, status)
+	assertEqual(t, "User not found", body["error"].(string))
+
+	status, body = get(t, s, "GET", "/users/a/recs")
+	assertEqual(t, http.StatusBadRequest, status)
+	assertEqual(t, "Invalid user id", body["error"].(string))
+
+	status, body = get(t, s, "GET", "/items/missing/recs")
+	assertEqual(t, http.StatusNotFound, status)
+	assertEqual(t, "Item not found", body["error"].(string))
+
+	status, body = get(t, s, "GET", "/users/1/recs?count=0")
+	assertEqual(t, http.StatusBadRequest, status)
+	assertEqual(t, "Invalid count", body["error"].(string))
+
+	status, body = get(t, s, "GET", "/users/1/similar?exclude=a")
+	assertEqual(t, http.StatusBadRequest, status)
+	assertEqual(t, "Invalid id", body["error"].(string))
+
+	status, body = get(t, s, "POST", "/reload")
+	assertEqual(t, http.StatusBadRequest, status)
+	assertEqual(t, "No model file", body["error"].(string))
+}
+
+func TestHealth(t *testing.T) {
+	s := server.New(fitModel(t, 20))
+
+	status, body := get(t, s, "GET", "/health")
+	assertEqual(t, http.StatusOK, status)
+	assertEqual(t, "ok", body["status"].(string))
+}
+
+func TestModel(t *testing.T) {
+	s := server.New(fitModel(t, 20))
+
+	status, body := get(t, s, "GET", "/model")
+	assertEqual(t, http.StatusOK, status)
+	assertEqual(t, float64(20), body["users"].(float64))
+	assertEqual(t, float64(10), body["items"].(float64))
+	assertEqual(t, float64(8), body["factors"].(float64))
+}
+
+func TestReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.gob")
+	saveModel(t, fitModel(t, 20), path)
+
+	s, err := server.Open[int, string](path)
+	assertNil(t, err)
+
+	_, body := get(t, s, "GET", "/model")
+	assertEqual(t, float64(20), body["users"].(float64))
+	assertEqual(t, path, body["path"].(string))
+
+	saveModel(t, fitModel(t, 30), path)
+
+	status, _ := get(t, s, "POST", "/reload")
+	assertEqual(t, http.StatusOK, status)
+
+	_, body = get(t, s, "GET", "/model")
+	assertEqual(t, float64(30), body["users"].(float64))
+
+	// keeps current model
+	assertNil(t, os.WriteFile(path, []byte("invalid"), 0644))
+	status, _ = get(t, s, "POST", "/reload")
+	assertEqual(t, http.StatusInternalServerError, status)
+
+	_, body = get(t, s, "GET", "/model")
+	assertEqual(t, float64(30), body["users"].(float64))
+}
+
+func TestWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.gob")
+	saveModel(t, fitModel(t, 20), path)
+
+	s, err := server.Open[int, string](path)
+	assertNil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.Watch(ctx, 10*time.Millisecond)
+	}()
+
+	saveModel(t, fitModel(t, 30), path)
+	future := time.Now().Add(time.Hour)
+	assertNil(t, os.Chtimes(path, future, future))
+
+	users := 0.0
+	for range 100 {
+		_, body := get(t, s, "GET", "/model")
+		users = body["users"].(float64)
+		if users == 30 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assertEqual(t, 30.0, users)
+
+	cancel()
+	assertEqual(t, context.Canceled, <-done)
+}
+
+func TestOpenMissing(t *testing.T) {
+	_, err := server.Open[int, string](filepath.Join(t.TempDir(), "missing.gob"))
+	assertEqual(t, true, err != nil && strings.Contains(err.Error(), "no such file"))
+}
+
+func TestConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.gob")
+	saveModel(t, fitModel(t, 20), path)
+
+	s, err := server.Open[int, string](path)
+	assertNil(t, err)
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Go(func() {
+			targets := []string{"/users/1/recs", "/items/item1/recs", "/users/1/similar", "/model"}
+			status, _ := get(t, s, "GET", targets[i%len(targets)])
+			assertEqual(t, http.StatusOK, status)
+		})
+	}
+	wg.Go(func() {
+		assertNil(t, s.Reload())
+	})
+	wg.Wait()
+}
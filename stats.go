@@ -0,0 +1,138 @@
+package disco
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Statistics for a dataset.
+type Stats struct {
+	// The number of ratings.
+	Ratings int
+	// The number of unique users.
+	Users int
+	// The number of unique items.
+	Items int
+	// The fraction of user-item pairs without a rating.
+	Sparsity float64
+	// The number of duplicate ratings for the same user and item.
+	Duplicates int
+	// The minimum value.
+	MinValue float32
+	// The maximum value.
+	MaxValue float32
+	// The mean value.
+	MeanValue float32
+	// The standard deviation of values.
+	StdDevValue float32
+	// The number of ratings per user.
+	UserRatings Counts
+	// The number of ratings per item.
+	ItemRatings Counts
+}
+
+// A summary of counts.
+type Counts struct {
+	Min    int
+	P25    int
+	Median int
+	P75    int
+	Max    int
+	Mean   float32
+}
+
+// Returns statistics for the dataset.
+func (d *Dataset[T, U]) Stats() Stats {
+	userCounts := make(map[T]int)
+	itemCounts := make(map[U]int)
+	seen := make(map[pair[T, U]]bool, len(d.data))
+	stats := Stats{Ratings: len(d.data)}
+
+	// welford's algorithm
+	var mean float64 = 0.0
+	var m2 float64 = 0.0
+
+	for i, rating := range d.data {
+		userCounts[rating.userId]++
+		itemCounts[rating.itemId]++
+
+		key := pair[T, U]{userId: rating.userId, itemId: rating.itemId}
+		if seen[key] {
+			stats.Duplicates++
+		} else {
+			seen[key] = true
+		}
+
+		if i == 0 || rating.value < stats.MinValue {
+			stats.MinValue = rating.value
+		}
+		if i == 0 || rating.value > stats.MaxValue {
+			stats.MaxValue = rating.value
+		}
+
+		value := float64(rating.value)
+		delta := value - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (value - mean)
+	}
+
+	stats.Users = len(userCounts)
+	stats.Items = len(itemCounts)
+	if len(d.data) > 0 {
+		stats.MeanValue = float32(mean)
+		stats.StdDevValue = float32(math.Sqrt(m2 / float64(len(d.data))))
+		stats.Sparsity = 1.0 - float64(len(seen))/(float64(stats.Users)*float64(stats.Items))
+	}
+	stats.UserRatings = summarizeCounts(userCounts)
+	stats.ItemRatings = summarizeCounts(itemCounts)
+
+	return stats
+}
+
+// Returns a human-readable summary.
+func (s Stats) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Ratings: %d\n", s.Ratings)
+	fmt.Fprintf(&b, "Users: %d\n", s.Users)
+	fmt.Fprintf(&b, "Items: %d\n", s.Items)
+	fmt.Fprintf(&b, "Sparsity: %.2f%%\n", s.Sparsity*100)
+	fmt.Fprintf(&b, "Duplicates: %d\n", s.Duplicates)
+	fmt.Fprintf(&b, "Values: min %g, max %g, mean %.2f, std dev %.2f\n", s.MinValue, s.MaxValue, s.MeanValue, s.StdDevValue)
+	fmt.Fprintf(&b, "Ratings per user: %s\n", s.UserRatings)
+	fmt.Fprintf(&b, "Ratings per item: %s", s.ItemRatings)
+	return b.String()
+}
+
+// Returns a human-readable summary.
+func (c Counts) String() string {
+	return fmt.Sprintf("min %d, 25%% %d, median %d, 75%% %d, max %d, mean %.2f", c.Min, c.P25, c.Median, c.P75, c.Max, c.Mean)
+}
+
+func summarizeCounts[T Id](counts map[T]int) Counts {
+	if len(counts) == 0 {
+		return Counts{}
+	}
+
+	sorted := make([]int, 0, len(counts))
+	sum := 0
+	for _, count := range counts {
+		sorted = append(sorted, count)
+		sum += count
+	}
+	slices.Sort(sorted)
+
+	quantile := func(p float64) int {
+		return sorted[int(math.Round(p*float64(len(sorted)-1)))]
+	}
+
+	return Counts{
+		Min:    sorted[0],
+		P25:    quantile(0.25),
+		Median: quantile(0.5),
+		P75:    quantile(0.75),
+		Max:    sorted[len(sorted)-1],
+		Mean:   float32(sum) / float32(len(sorted)),
+	}
+}